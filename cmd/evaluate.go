@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/detector"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/evaluation"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/render"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate",
+	Short: "Run the detector and evaluate the detections against the ground-truth labels.",
+	Long:  "Run the detector and compare the detections with the ground-truth labels. The precision, recall and F1 scores are reported at the frame and the event level.",
+	RunE:  runEvaluate,
+}
+
+var (
+	LabelsPath string
+)
+
+func init() {
+	evaluateCmd.Flags().StringVarP(&LabelsPath, "labels-path", "l", "", "Path to the ground-truth labels file. Each line is a frame ordinal number, a range of frames (e.g. 120-135), or a timestamp or range of timestamps (e.g. 4.5s-5s or 00:01:04.5-00:01:05).")
+	evaluateCmd.MarkFlagRequired("labels-path")
+
+	rootCmd.AddCommand(evaluateCmd)
+}
+
+func runEvaluate(cmd *cobra.Command, args []string) error {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	if len(InputVideoPath) == 0 && len(DetectorOptions.AnalysisCachePath) == 0 {
+		return errors.New("cmd: the input video path or the analysis cache path must be specified")
+	}
+
+	labels, err := evaluation.LoadLabels(LabelsPath)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to load the labels: %w", err)
+	}
+
+	renderer := render.CreateRenderer(VerboseMode)
+	detectorInstance, err := detector.CreateDetector(renderer, DetectorOptions)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to create the detector instance: %w", err)
+	}
+
+	result, err := detectorInstance.Run(InputVideoPath, OutputDirectoryPath)
+	if err != nil {
+		return fmt.Errorf("cmd: detector run failed: %w", err)
+	}
+
+	evaluationResult, err := evaluateDetectionResult(labels, result)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to evaluate the detection result: %w", err)
+	}
+
+	renderer.Table([][]string{
+		{"", "True positives", "False positives", "False negatives", "Precision", "Recall", "F1"},
+		scoresToBuffer("Frames", evaluationResult.FrameScores),
+		scoresToBuffer("Events", evaluationResult.EventScores),
+	})
+
+	if err := exportEvaluationReports(OutputDirectoryPath, evaluationResult); err != nil {
+		return fmt.Errorf("cmd: failed to export the evaluation reports: %w", err)
+	}
+
+	renderer.LogInfo("Evaluation reports exported to: %s", OutputDirectoryPath)
+	return nil
+}
+
+// Helper function used to compare the detection result with the labels converted to the frames ranges. Only the analyzed frames
+// are scored, so the labels outside the analyzed range or skipped by the frame stride are ignored.
+func evaluateDetectionResult(labels *evaluation.Labels, result detector.DetectionResult) (*evaluation.Evaluation, error) {
+	labelsRanges, err := labels.GetFrameRanges(result.Video.FPS, result.Video.StartTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("cmd: failed to convert the labels to frames ranges: %w", err)
+	}
+
+	eventsRanges := make([]evaluation.FrameRange, 0, len(result.Events))
+	for _, event := range result.Events {
+		eventsRanges = append(eventsRanges, evaluation.FrameRange{First: event.FirstFrame, Last: event.LastFrame})
+	}
+
+	return evaluation.EvaluateAnalyzedFrames(labelsRanges, result.Detections, eventsRanges, result.GetAnalyzedFrames()), nil
+}
+
+// Helper function used to export the evaluation JSON report and the per-frame confusion CSV report to the output directory.
+func exportEvaluationReports(outputDirectoryPath string, evaluationResult *evaluation.Evaluation) error {
+	jsonReportFile, err := utils.CreateFileWithTree(path.Join(outputDirectoryPath, "evaluation-report.json"))
+	if err != nil {
+		return fmt.Errorf("cmd: failed to create the json evaluation report file: %w", err)
+	}
+
+	defer jsonReportFile.Close()
+
+	if err := evaluationResult.ExportJsonReport(jsonReportFile); err != nil {
+		return fmt.Errorf("cmd: failed to export the json evaluation report: %w", err)
+	}
+
+	csvReportFile, err := utils.CreateFileWithTree(path.Join(outputDirectoryPath, "confusion-report.csv"))
+	if err != nil {
+		return fmt.Errorf("cmd: failed to create the csv confusion report file: %w", err)
+	}
+
+	defer csvReportFile.Close()
+
+	if err := evaluationResult.ExportConfusionCsvReport(csvReportFile); err != nil {
+		return fmt.Errorf("cmd: failed to export the csv confusion report: %w", err)
+	}
+
+	return nil
+}
+
+func scoresToBuffer(name string, scores evaluation.Scores) []string {
+	return []string{
+		name,
+		strconv.Itoa(scores.TruePositives),
+		strconv.Itoa(scores.FalsePositives),
+		strconv.Itoa(scores.FalseNegatives),
+		strconv.FormatFloat(scores.Precision, 'f', 4, 64),
+		strconv.FormatFloat(scores.Recall, 'f', 4, 64),
+		strconv.FormatFloat(scores.F1, 'f', 4, 64),
+	}
+}
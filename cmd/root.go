@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/Krzysztofz01/video-lightning-detector/internal/detector"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
 	"github.com/Krzysztofz01/video-lightning-detector/internal/render"
 )
 
@@ -15,49 +19,115 @@ var rootCmd = &cobra.Command{
 	Short: "",
 	Long:  "",
 	RunE:  run,
+
+	PersistentPreRunE: applyOptions,
 }
 
 var (
 	InputVideoPath      string
 	OutputDirectoryPath string
 	VerboseMode         bool
+	ConfigPath          string
+	PresetName          string
 	DetectorOptions     detector.DetectorOptions = detector.GetDefaultDetectorOptions()
+	MetricThresholds    map[string]*float64      = make(map[string]*float64)
+	MetricWeights       map[string]*float64      = make(map[string]*float64)
+	SustainThresholds   map[string]*float64      = make(map[string]*float64)
 )
 
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
-	rootCmd.PersistentFlags().StringVarP(&InputVideoPath, "input-video-path", "i", "", "Input video to perform the lightning detection.")
-	rootCmd.MarkPersistentFlagRequired("input-video-path")
+	rootCmd.PersistentFlags().StringVarP(&InputVideoPath, "input-video-path", "i", "", "Input video or image sequence to perform the lightning detection. The image sequence can be specified as a directory, a glob pattern or a printf pattern of PNG or JPEG images. The stream of raw RGBA frames is read from the file, the named pipe or the standard input specified as \"-\" when the raw stream frame size is provided. Optional when the analysis cache is provided.")
 
 	rootCmd.PersistentFlags().StringVarP(&OutputDirectoryPath, "output-directory-path", "o", "", "Output directory to store detected frames.")
 	rootCmd.MarkPersistentFlagRequired("output-directory-path")
 
 	rootCmd.PersistentFlags().BoolVarP(&VerboseMode, "verbose", "v", false, "Enable verbose logging.")
 
+	rootCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Path to a YAML or JSON config file with the detector options and the named presets. The explicitly provided flags are overriding the config values.")
+
+	rootCmd.PersistentFlags().StringVar(&PresetName, "preset", "", "Name of the config file preset applied on top of the config file options.")
+
 	rootCmd.PersistentFlags().BoolVarP(
 		&DetectorOptions.AutoThresholds,
 		"auto-thresholds", "a",
 		DetectorOptions.AutoThresholds,
 		"Automatically select thresholds for all parameters based on calculated frame values. Values that are explicitly provided will not be overwritten.")
 
-	rootCmd.PersistentFlags().Float64VarP(
-		&DetectorOptions.ColorDifferenceDetectionThreshold,
-		"color-difference-threshold", "c",
-		DetectorOptions.ColorDifferenceDetectionThreshold,
-		"The threshold used to determine the difference between two neighbouring frames on the color basis. Detection is credited when the value for a given frame is greater than the sum of the threshold of tripping and the moving average.")
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.AutoThresholdsStrategy,
+		"auto-thresholds-strategy",
+		DetectorOptions.AutoThresholdsStrategy,
+		"The strategy used to calculate the automatic thresholds. The \"mean-deviation\" strategy uses the mean of the positive differences between the frame values and the moving mean. The \"mad\" strategy uses the multiple of the median absolute deviation and the moving median is used as the detection baseline instead of the moving mean.")
 
-	rootCmd.PersistentFlags().Float64VarP(
-		&DetectorOptions.BinaryThresholdDifferenceDetectionThreshold,
-		"binary-threshold-difference-threshold", "t",
-		DetectorOptions.BinaryThresholdDifferenceDetectionThreshold,
-		"The threshold used to determine the difference between two neighbouring frames after the binary thresholding process. Detection is credited when the value for a given frame is greater than the sum of the threshold of tripping and the moving average")
+	rootCmd.PersistentFlags().Float64Var(
+		&DetectorOptions.AutoThresholdsMadMultiplier,
+		"auto-thresholds-mad-multiplier",
+		DetectorOptions.AutoThresholdsMadMultiplier,
+		"The multiplier of the median absolute deviation used by the \"mad\" automatic thresholds strategy.")
 
-	rootCmd.PersistentFlags().Float64VarP(
-		&DetectorOptions.BrightnessDetectionThreshold,
-		"brightness-threshold", "b",
-		DetectorOptions.BrightnessDetectionThreshold,
-		"The threshold used to determine the brightness of the frame. Detection is credited when the value for a given frame is greater than the sum of the threshold of tripping and the moving average")
+	for _, name := range frame.GetMetricsNames() {
+		threshold := DetectorOptions.GetMetricThreshold(name)
+		MetricThresholds[name] = &threshold
+
+		rootCmd.PersistentFlags().Float64VarP(
+			MetricThresholds[name],
+			fmt.Sprintf("%s-threshold", name), thresholdsShorthands[name],
+			threshold,
+			fmt.Sprintf("The threshold of the %s parameter of the frame. Detection is credited when the value for a given frame is greater than the sum of the threshold of tripping and the moving average.", name))
+	}
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.DetectionMode,
+		"detection-mode",
+		DetectorOptions.DetectionMode,
+		"The mode used to determine if the frame is detected. In the \"threshold\" mode the frame value must exceed the sum of the moving mean and the threshold. In the \"z-score\" mode the difference between the frame value and the moving mean divided by the moving standard deviation must exceed the sigma, for each parameter.")
+
+	rootCmd.PersistentFlags().Float64Var(
+		&DetectorOptions.ZScoreSigma,
+		"z-score-sigma",
+		DetectorOptions.ZScoreSigma,
+		"The number of the moving standard deviations by which the frame value must exceed the moving mean in the \"z-score\" detection mode.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.CombinationRule,
+		"combination-rule",
+		DetectorOptions.CombinationRule,
+		"The rule used to combine the results of the parameters checks. The \"all\" and \"any\" rules require all or any of the parameters to meet the requirements, the \"k-of-n\" rule requires the minimum number of the parameters and the \"weighted\" rule compares the weighted mean of the parameters normalized margins with the score threshold.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.CombinationMinimumMetrics,
+		"combination-minimum-metrics",
+		DetectorOptions.CombinationMinimumMetrics,
+		"The minimum number of the parameters meeting the requirements for the frame to be detected using the \"k-of-n\" combination rule.")
+
+	rootCmd.PersistentFlags().Float64Var(
+		&DetectorOptions.CombinationScoreThreshold,
+		"combination-score-threshold",
+		DetectorOptions.CombinationScoreThreshold,
+		"The threshold of the weighted mean of the parameters normalized margins for the frame to be detected using the \"weighted\" combination rule. The margin is the difference between the frame value and the sum of the moving mean and the threshold, divided by the threshold, or by the moving standard deviation in the \"z-score\" detection mode, so zero means that the parameters are at the threshold on average.")
+
+	for _, name := range frame.GetMetricsNames() {
+		weight := DetectorOptions.GetMetricWeight(name)
+		MetricWeights[name] = &weight
+
+		rootCmd.PersistentFlags().Float64Var(
+			MetricWeights[name],
+			fmt.Sprintf("%s-weight", name),
+			weight,
+			fmt.Sprintf("The weight of the %s parameter used by the \"weighted\" combination rule.", name))
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		SustainThresholds[name] = new(float64)
+
+		rootCmd.PersistentFlags().Float64Var(
+			SustainThresholds[name],
+			fmt.Sprintf("%s-sustain", name),
+			0.0,
+			fmt.Sprintf("The lower threshold of the %s parameter used instead of the detection threshold, or the z-score sigma in the \"z-score\" detection mode, to keep detecting the frames following a detected frame, so the decaying tail of the lightning is captured. The hysteresis is disabled if no sustain threshold is specified.", name))
+	}
 
 	rootCmd.PersistentFlags().Int32VarP(
 		&DetectorOptions.MovingMeanResolution,
@@ -65,12 +135,102 @@ func init() {
 		DetectorOptions.MovingMeanResolution,
 		"The number of elements of the subset on which the moving mean will be calculated, for each parameter.")
 
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.EventFramesGap,
+		"event-frames-gap",
+		DetectorOptions.EventFramesGap,
+		"The maximum number of not detected frames between two detected frames for them to be grouped into a single lightning event.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.DetectionWindowLength,
+		"detection-window-length",
+		DetectorOptions.DetectionWindowLength,
+		"The number of consecutive frames in which the not detected frames between two detected frames are treated as detected. Longer windows are delaying the streaming detection.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.DetectionMaxGap,
+		"detection-max-gap",
+		DetectorOptions.DetectionMaxGap,
+		"The maximum number of not detected frames between two detected frames in the detection window which are treated as detected. Must not exceed the detection window length minus two.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.DetectionMinRunLength,
+		"detection-min-run-length",
+		DetectorOptions.DetectionMinRunLength,
+		"The minimum number of consecutive detected frames, including the frames treated as detected, for them to be detected. Shorter runs such as single frame blips are dropped.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.DetectionMaxRunLength,
+		"detection-max-run-length",
+		DetectorOptions.DetectionMaxRunLength,
+		"The maximum number of consecutive detected frames, including the frames treated as detected, for them to be detected. Longer runs such as lights switching on are dropped. Not limited if set to zero.")
+
 	rootCmd.PersistentFlags().BoolVarP(
 		&DetectorOptions.SkipFramesExport,
 		"skip-frames-export", "f",
 		DetectorOptions.SkipFramesExport,
 		"Value indicating if the detected frames should not be exported.")
 
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.Streaming,
+		"streaming",
+		DetectorOptions.Streaming,
+		"Perform the analysis, detection and frames export in a single pass over the video, storing only a bounded window of frames. Not compatible with the auto-thresholds, the analysis cache, the frames reports and the explain report.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.RawStreamWidth,
+		"raw-stream-width",
+		DetectorOptions.RawStreamWidth,
+		"The width of the frames of the input stream of raw RGBA frames, such as the output of ffmpeg with the rawvideo format and the rgba pixel format. Requires the streaming mode.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.RawStreamHeight,
+		"raw-stream-height",
+		DetectorOptions.RawStreamHeight,
+		"The height of the frames of the input stream of raw RGBA frames. Requires the streaming mode.")
+
+	rootCmd.PersistentFlags().Float64Var(
+		&DetectorOptions.RawStreamFPS,
+		"raw-stream-fps",
+		DetectorOptions.RawStreamFPS,
+		"The frame rate of the input stream of raw RGBA frames. Optional, used only for the reports and the analysis range timestamps.")
+
+	rootCmd.PersistentFlags().Float64Var(
+		&DetectorOptions.SequenceFPS,
+		"sequence-fps",
+		DetectorOptions.SequenceFPS,
+		"The frame rate of the input image sequence. Optional, required for the frames timestamps, the analysis range timestamps, the wall-clock times and the clips export.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.ExportClips,
+		"export-clips",
+		DetectorOptions.ExportClips,
+		"Export a MP4 video clip for each detected lightning event.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.ClipPreRollFrames,
+		"clip-pre-roll",
+		DetectorOptions.ClipPreRollFrames,
+		"The number of frames preceding the lightning event included in the exported clip.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.ClipPostRollFrames,
+		"clip-post-roll",
+		DetectorOptions.ClipPostRollFrames,
+		"The number of frames following the lightning event included in the exported clip.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.ExportComposite,
+		"export-composite",
+		DetectorOptions.ExportComposite,
+		"Export the composite.png image of all detected frames blended onto the background frame preceding the first lightning event.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.ExportEventComposites,
+		"export-event-composites",
+		DetectorOptions.ExportEventComposites,
+		"Export a composite image of the detected frames for each lightning event blended onto the background frame preceding the event.")
+
 	// Extra quiet mode for detections: suppress per-frame positive Info logs to keep output concise.
 	rootCmd.PersistentFlags().BoolVar(
 		&DetectorOptions.QuietDetections,
@@ -96,6 +256,12 @@ func init() {
 		DetectorOptions.ExportChartReport,
 		"Value indicating if the frames statistics chart in HTML format should be exported.")
 
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.ExportExplainReport,
+		"export-explain-report",
+		DetectorOptions.ExportExplainReport,
+		"Export the detections-explain.csv and detections-explain.json reports with the metrics values, moving means, thresholds and margins of every frame and the frame status.")
+
 	// Long-only flag to export a concise timings summary (JSON) alongside other outputs.
 	rootCmd.PersistentFlags().BoolVar(
 		&DetectorOptions.ExportTimingsReport,
@@ -103,12 +269,138 @@ func init() {
 		DetectorOptions.ExportTimingsReport,
 		"Export per-stage and total timings as timings.json into the output directory.")
 
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.ExportAnalysisCache,
+		"export-analysis-cache",
+		DetectorOptions.ExportAnalysisCache,
+		"Export the analyzed frames together with the video metadata and options as analysis-cache.json into the output directory.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.AnalysisCachePath,
+		"analysis-cache",
+		DetectorOptions.AnalysisCachePath,
+		"Path to a previously exported analysis cache file. The video analysis stage is skipped and the cached frames are used for the detection.")
+
+	rootCmd.PersistentFlags().Float64SliceVar(
+		&DetectorOptions.StatisticsPercentiles,
+		"statistics-percentiles",
+		DetectorOptions.StatisticsPercentiles,
+		"The comma-separated percentiles of the frames metrics values included in the frames statistics.")
+
 	rootCmd.PersistentFlags().Float64VarP(
 		&DetectorOptions.FrameScalingFactor,
 		"scaling-factor", "s",
 		DetectorOptions.FrameScalingFactor,
 		"The frame scaling factor used to downscale frames for better performance.")
 
+	rootCmd.PersistentFlags().StringArrayVar(
+		&DetectorOptions.IncludeRegions,
+		"include-region",
+		DetectorOptions.IncludeRegions,
+		"Region of the frame taken under account by the frame metrics, specified in original video pixels as a rectangle \"x,y,width,height\" or a polygon \"x1,y1;x2,y2;x3,y3\". Can be specified multiple times.")
+
+	rootCmd.PersistentFlags().StringArrayVar(
+		&DetectorOptions.ExcludeRegions,
+		"exclude-region",
+		DetectorOptions.ExcludeRegions,
+		"Region of the frame ignored by the frame metrics, specified in original video pixels as a rectangle \"x,y,width,height\" or a polygon \"x1,y1;x2,y2;x3,y3\". Can be specified multiple times.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.MaskImagePath,
+		"mask-path",
+		DetectorOptions.MaskImagePath,
+		"Path to a black and white PNG mask image. Only the frame pixels corresponding to the white mask pixels are taken under account by the frame metrics.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.TileColumns,
+		"tile-columns",
+		DetectorOptions.TileColumns,
+		"The number of columns of the grid splitting the frames into tiles. The metrics are calculated separately for each tile and the frame is detected if any tile meets the requirements compared to its own moving mean, so small and distant strikes are not diluted by the whole frame.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.TileRows,
+		"tile-rows",
+		DetectorOptions.TileRows,
+		"The number of rows of the grid splitting the frames into tiles.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.MotionCompensation,
+		"motion-compensation",
+		DetectorOptions.MotionCompensation,
+		"Estimate the global translation between the consecutive frames and compensate it before the difference metrics are calculated, so the camera panning of the handheld and dashcam footage is not detected. The estimated shift of each frame is included in the frames reports.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.MotionMaxShift,
+		"motion-max-shift",
+		DetectorOptions.MotionMaxShift,
+		"The maximal estimated translation between the consecutive frames in original video pixels in each direction.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.SceneCutDetection,
+		"scene-cut-detection",
+		DetectorOptions.SceneCutDetection,
+		"Classify the frames meeting the detection requirements as the scene cuts if the scene does not return within the next three analyzed frames, which is the case for the hard cuts of the compilation videos, and exclude them from the detections. The color histogram of the frame preceding the frame is compared with the next frames. The scene cuts are listed in the cuts reports. Not compatible with the streaming mode.")
+
+	rootCmd.PersistentFlags().Float64Var(
+		&DetectorOptions.SceneCutThreshold,
+		"scene-cut-threshold",
+		DetectorOptions.SceneCutThreshold,
+		"The minimal color histogram distance from zero to one between the frame preceding the frame and each of the next three analyzed frames for it to be classified as the scene cut.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.AnalysisStart,
+		"start",
+		DetectorOptions.AnalysisStart,
+		"The first frame of the analyzed range of the video, specified as the frame number or the timestamp in the \"hh:mm:ss.fff\", \"mm:ss.fff\" or \"90.5s\" format. The frames numbers in the reports are matching the original video.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.AnalysisEnd,
+		"end",
+		DetectorOptions.AnalysisEnd,
+		"The last frame of the analyzed range of the video, specified as the frame number or the timestamp in the same format as the start. The range is analyzed to the end of the video if not specified.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.FrameStride,
+		"frame-stride",
+		DetectorOptions.FrameStride,
+		"Analyze only every n-th frame of the analyzed range, which is useful for a coarse first pass over long videos. The analyzed frames are compared with the directly preceding frames of the video. The detection window, the detection gaps and runs lengths and the event frames gap are counted in the analyzed frames.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.WallClockStart,
+		"wall-clock-start",
+		DetectorOptions.WallClockStart,
+		"The wall-clock time of the first frame of the video, specified in the RFC 3339 format or as \"yyyy-mm-dd hh:mm:ss.fff\" in the wall-clock time zone, or \"creation-time\" to use the creation time of the video container. The wall-clock times of the frames are included in the reports and the exported frames names.")
+
+	rootCmd.PersistentFlags().StringVar(
+		&DetectorOptions.WallClockTimezone,
+		"wall-clock-timezone",
+		DetectorOptions.WallClockTimezone,
+		"The IANA name of the time zone, such as \"Europe/Warsaw\", in which the wall-clock start without the offset is specified and the wall-clock times of the frames are reported.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.LocalizeStrikes,
+		"localize-strikes",
+		DetectorOptions.LocalizeStrikes,
+		"Localize the lightning strikes by finding the largest regions of the detected frames which changed compared to the frame preceding the event. The bounding boxes, centroids and areas of the regions are included in the frames and events reports. Not compatible with the streaming mode.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.LocalizationRegionsLimit,
+		"localization-regions",
+		DetectorOptions.LocalizationRegionsLimit,
+		"The maximum number of the largest changed regions found for each detected frame by the strike localization.")
+
+	rootCmd.PersistentFlags().Int32Var(
+		&DetectorOptions.LocalizationMinimumArea,
+		"localization-min-area",
+		DetectorOptions.LocalizationMinimumArea,
+		"The minimum area in original video pixels of the changed regions found by the strike localization.")
+
+	rootCmd.PersistentFlags().BoolVar(
+		&DetectorOptions.DrawRegions,
+		"draw-regions",
+		DetectorOptions.DrawRegions,
+		"Draw the bounding boxes of the changed regions found by the strike localization onto the exported frames images.")
+
 	rootCmd.PersistentFlags().BoolVarP(
 		&DetectorOptions.Denoise,
 		"denoise", "n",
@@ -124,6 +416,85 @@ func Execute(args []string) {
 	}
 }
 
+// Map of the frame metrics names to the shorthands of the corresponding threshold flags.
+var thresholdsShorthands = map[string]string{
+	frame.BrightnessMetricName:                "b",
+	frame.ColorDifferenceMetricName:           "c",
+	frame.BinaryThresholdDifferenceMetricName: "t",
+}
+
+// Helper function used to override the detector options with the config file options and the selected preset and to apply the
+// metrics thresholds, weights and sustain thresholds provided by the flags. The thresholds provided by the flags are marked as
+// explicit. The flags that were explicitly provided are re-applied after the config, so they take precedence over the config values.
+func applyOptions(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if name := strings.TrimSuffix(flag.Name, "-threshold"); name != flag.Name && frame.IsMetricName(name) {
+			DetectorOptions.SetMetricThreshold(name, *MetricThresholds[name])
+			DetectorOptions.SetMetricThresholdExplicit(name)
+		}
+
+		if name := strings.TrimSuffix(flag.Name, "-weight"); name != flag.Name && frame.IsMetricName(name) {
+			DetectorOptions.SetMetricWeight(name, *MetricWeights[name])
+		}
+
+		if name := strings.TrimSuffix(flag.Name, "-sustain"); name != flag.Name && frame.IsMetricName(name) {
+			DetectorOptions.SetMetricSustainThreshold(name, *SustainThresholds[name])
+		}
+	})
+
+	return nil
+}
+
+func applyConfig(cmd *cobra.Command) error {
+	if len(ConfigPath) == 0 {
+		if len(PresetName) != 0 {
+			return errors.New("cmd: the preset can only be selected when the config file is specified")
+		}
+
+		return nil
+	}
+
+	config, err := detector.LoadDetectorConfig(ConfigPath)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to load the config: %w", err)
+	}
+
+	changedFlags := make([]*pflag.Flag, 0)
+	changedValues := make([]interface{}, 0)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		changedFlags = append(changedFlags, flag)
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			changedValues = append(changedValues, sliceValue.GetSlice())
+		} else {
+			changedValues = append(changedValues, flag.Value.String())
+		}
+	})
+
+	if err := config.Apply(&DetectorOptions, PresetName); err != nil {
+		return fmt.Errorf("cmd: failed to apply the config: %w", err)
+	}
+
+	for index, flag := range changedFlags {
+		var err error
+		switch value := changedValues[index].(type) {
+		case []string:
+			err = flag.Value.(pflag.SliceValue).Replace(value)
+		case string:
+			err = flag.Value.Set(value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("cmd: failed to re-apply the %s flag value: %w", flag.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func run(cmd *cobra.Command, args []string) error {
 	defer func() {
 		if err := recover(); err != nil {
@@ -131,13 +502,17 @@ func run(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
+	if len(InputVideoPath) == 0 && len(DetectorOptions.AnalysisCachePath) == 0 {
+		return errors.New("cmd: the input video path or the analysis cache path must be specified")
+	}
+
 	renderer := render.CreateRenderer(VerboseMode)
 	detectorInstance, err := detector.CreateDetector(renderer, DetectorOptions)
 	if err != nil {
 		return fmt.Errorf("cmd: failed to create the detector instance: %w", err)
 	}
 
-	if err := detectorInstance.Run(InputVideoPath, OutputDirectoryPath); err != nil {
+	if _, err := detectorInstance.Run(InputVideoPath, OutputDirectoryPath); err != nil {
 		return fmt.Errorf("cmd: detector run failed: %w", err)
 	}
 
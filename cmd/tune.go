@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/detector"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/evaluation"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/render"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/tuning"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+const (
+	TunedOptionsFileName string = "tuned-options.json"
+	tuningReportFileName string = "tuning-report.csv"
+	tuningTableSize      int    = 10
+)
+
+var tuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "Search for the detector options giving the best accuracy on the analysis cache and the ground-truth labels.",
+	Long:  "Search for the thresholds and the moving mean resolution giving the best F1 score on the analysis cache and the ground-truth labels. The ranking of the evaluated options and the best options are exported to the output directory.",
+	RunE:  runTune,
+}
+
+var (
+	TuneSearchStrategy              string
+	TuneObjective                   string
+	TuneThresholdsValues            map[string]*[]float64 = make(map[string]*[]float64)
+	TuneMovingMeanResolutionsValues []int32
+)
+
+func init() {
+	tuneCmd.Flags().StringVarP(&LabelsPath, "labels-path", "l", "", "Path to the ground-truth labels file. Each line is a frame ordinal number, a range of frames (e.g. 120-135), or a timestamp or range of timestamps (e.g. 4.5s-5s or 00:01:04.5-00:01:05).")
+	tuneCmd.MarkFlagRequired("labels-path")
+
+	tuneCmd.Flags().StringVar(
+		&TuneSearchStrategy,
+		"search-strategy",
+		tuning.CoordinateDescentSearchStrategy,
+		"The strategy used to search the options. The \"coordinate-descent\" strategy optimizes one option at a time and the \"grid\" strategy evaluates all combinations, which is exhaustive but much slower.")
+
+	tuneCmd.Flags().StringVar(
+		&TuneObjective,
+		"objective",
+		tuning.FrameObjective,
+		"The level of the F1 score used to rank the options. Either \"frame\" or \"event\".")
+
+	for _, name := range frame.GetMetricsNames() {
+		values := []float64{0.0, 0.005, 0.01, 0.02, 0.03, 0.05, 0.075, 0.1}
+		TuneThresholdsValues[name] = &values
+
+		tuneCmd.Flags().Float64SliceVar(
+			TuneThresholdsValues[name],
+			fmt.Sprintf("%s-threshold-values", name),
+			values,
+			fmt.Sprintf("The comma-separated candidate values of the %s detection threshold.", name))
+	}
+
+	tuneCmd.Flags().Int32SliceVar(
+		&TuneMovingMeanResolutionsValues,
+		"moving-mean-resolution-values",
+		[]int32{10, 25, 50, 75, 100},
+		"The comma-separated candidate values of the moving mean resolution.")
+
+	rootCmd.AddCommand(tuneCmd)
+}
+
+func runTune(cmd *cobra.Command, args []string) error {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	if len(DetectorOptions.AnalysisCachePath) == 0 {
+		return errors.New("cmd: the analysis cache path must be specified for the tuning")
+	}
+
+	labels, err := evaluation.LoadLabels(LabelsPath)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to load the labels: %w", err)
+	}
+
+	renderer := render.CreateRenderer(VerboseMode)
+
+	cache, err := detector.LoadAnalysisCache(DetectorOptions.AnalysisCachePath)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to load the analysis cache: %w", err)
+	}
+
+	frames, err := cache.GetFramesCollection()
+	if err != nil {
+		return fmt.Errorf("cmd: failed to access the analysis cache frames: %w", err)
+	}
+
+	labelsRanges, err := labels.GetFrameRanges(cache.Video.FPS, cache.Video.StartTimestamp)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to convert the labels to frames ranges: %w", err)
+	}
+
+	tuner, err := tuning.CreateTuner(frames, labelsRanges, DetectorOptions, TuneObjective)
+	if err != nil {
+		return fmt.Errorf("cmd: failed to create the tuner instance: %w", err)
+	}
+
+	space := tuning.SearchSpace{
+		Thresholds:            make(map[string][]float64),
+		MovingMeanResolutions: TuneMovingMeanResolutionsValues,
+	}
+
+	for name, values := range TuneThresholdsValues {
+		space.Thresholds[name] = *values
+	}
+
+	combinations := tuning.GetSearchSpaceCombinationsCount(space)
+	if TuneSearchStrategy == tuning.GridSearchStrategy {
+		renderer.LogInfo("Searching %d options combinations.", combinations)
+	} else {
+		renderer.LogInfo("Searching at most %d options combinations.", combinations)
+	}
+
+	spinnerStop := renderer.Spinner("Searching the detector options")
+	candidates, err := tuner.Search(space, TuneSearchStrategy)
+	spinnerStop()
+
+	if err != nil {
+		return fmt.Errorf("cmd: failed to search the detector options: %w", err)
+	}
+
+	renderer.LogInfo("Evaluated %d options combinations.", len(candidates))
+	if invalid := tuner.GetInvalidCombinationsCount(); invalid != 0 {
+		renderer.LogWarning("Skipped %d invalid options combinations.", invalid)
+	}
+	renderer.Table(candidatesToTable(candidates))
+
+	if err := exportTuningReports(OutputDirectoryPath, candidates); err != nil {
+		return fmt.Errorf("cmd: failed to export the tuning reports: %w", err)
+	}
+
+	renderer.LogInfo("Best options exported to: %s", path.Join(OutputDirectoryPath, TunedOptionsFileName))
+	return nil
+}
+
+// Helper function used to export the candidates ranking CSV report and the best options JSON file to the output directory.
+func exportTuningReports(outputDirectoryPath string, candidates []*tuning.Candidate) error {
+	reportFile, err := utils.CreateFileWithTree(path.Join(outputDirectoryPath, tuningReportFileName))
+	if err != nil {
+		return fmt.Errorf("cmd: failed to create the tuning report file: %w", err)
+	}
+
+	defer reportFile.Close()
+
+	if err := tuning.ExportCandidatesCsvReport(reportFile, candidates); err != nil {
+		return fmt.Errorf("cmd: failed to export the tuning report: %w", err)
+	}
+
+	optionsFile, err := utils.CreateFileWithTree(path.Join(outputDirectoryPath, TunedOptionsFileName))
+	if err != nil {
+		return fmt.Errorf("cmd: failed to create the tuned options file: %w", err)
+	}
+
+	defer optionsFile.Close()
+
+	// NOTE: The analysis cache path is cleared, so the options can be reused for the detection on other videos.
+	options := candidates[0].Options
+	options.AnalysisCachePath = ""
+
+	encoder := json.NewEncoder(optionsFile)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(options); err != nil {
+		return fmt.Errorf("cmd: failed to encode the tuned options: %w", err)
+	}
+
+	return nil
+}
+
+func candidatesToTable(candidates []*tuning.Candidate) [][]string {
+	header := []string{"Rank"}
+	for _, name := range frame.GetMetricsNames() {
+		header = append(header, name)
+	}
+
+	table := [][]string{append(header, "Moving mean resolution", "Frame F1", "Event F1")}
+	for index, candidate := range candidates {
+		if index == tuningTableSize {
+			break
+		}
+
+		row := []string{strconv.Itoa(index + 1)}
+		for _, name := range frame.GetMetricsNames() {
+			row = append(row, strconv.FormatFloat(candidate.Options.GetMetricThreshold(name), 'f', -1, 64))
+		}
+
+		table = append(table, append(row,
+			strconv.Itoa(int(candidate.Options.MovingMeanResolution)),
+			strconv.FormatFloat(candidate.Evaluation.FrameScores.F1, 'f', 4, 64),
+			strconv.FormatFloat(candidate.Evaluation.EventScores.F1, 'f', 4, 64)))
+	}
+
+	return table
+}
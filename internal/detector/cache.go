@@ -0,0 +1,149 @@
+package detector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
+const (
+	AnalysisCacheFileName string = "analysis-cache.json"
+	analysisCacheVersion  int    = 4
+)
+
+// Structure representing the metadata of the analyzed video. The start timestamp is the presentation timestamp of the first
+// frame and the wall-clock start is the wall-clock time of the first frame, which is nil if unknown.
+type VideoMetadata struct {
+	Path           string     `json:"path"`
+	Width          int        `json:"width"`
+	Height         int        `json:"height"`
+	Frames         int        `json:"frames"`
+	FPS            float64    `json:"fps"`
+	Duration       float64    `json:"duration"`
+	StartTimestamp float64    `json:"start-timestamp"`
+	WallClockStart *time.Time `json:"wall-clock-start,omitempty"`
+}
+
+// Structure representing the persisted results of the video analysis stage together with the video metadata and the options
+// that were used to produce them. The cache allows to re-run the detection without decoding the video again.
+type AnalysisCache struct {
+	Version int             `json:"version"`
+	Video   VideoMetadata   `json:"video"`
+	Options DetectorOptions `json:"options"`
+	Frames  []*frame.Frame  `json:"frames"`
+}
+
+// Create a new analysis cache instance from the video metadata, the detector options and the analyzed frames collection.
+func CreateAnalysisCache(video VideoMetadata, options DetectorOptions, frames *frame.FramesCollection) *AnalysisCache {
+	return &AnalysisCache{
+		Version: analysisCacheVersion,
+		Video:   video,
+		Options: options,
+		Frames:  frames.GetAll(),
+	}
+}
+
+// Read and decode the analysis cache from the file specified by the path.
+func LoadAnalysisCache(path string) (*AnalysisCache, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("detector: failed to open the analysis cache file: %w", err)
+	}
+
+	defer file.Close()
+
+	return ImportAnalysisCache(file)
+}
+
+// Decode the analysis cache from the provided reader which can be a file reference.
+func ImportAnalysisCache(file io.Reader) (*AnalysisCache, error) {
+	cache := &AnalysisCache{}
+	if err := json.NewDecoder(file).Decode(cache); err != nil {
+		return nil, fmt.Errorf("detector: failed to decode the analysis cache: %w", err)
+	}
+
+	if cache.Version != analysisCacheVersion {
+		return nil, fmt.Errorf("detector: unsupported analysis cache version %d (expected %d)", cache.Version, analysisCacheVersion)
+	}
+
+	if len(cache.Frames) == 0 {
+		return nil, errors.New("detector: the analysis cache does not contain any frames")
+	}
+
+	return cache, nil
+}
+
+// Write the JSON format analysis cache to the provided writer which can be a file reference.
+func (cache *AnalysisCache) Export(file io.Writer) error {
+	encoder := json.NewEncoder(file)
+
+	if err := encoder.Encode(cache); err != nil {
+		return fmt.Errorf("detector: failed to encode the analysis cache: %w", err)
+	}
+
+	return nil
+}
+
+// Create a new frames collection from the frames stored in the analysis cache.
+func (cache *AnalysisCache) GetFramesCollection() (*frame.FramesCollection, error) {
+	frames := frame.CreateNewFramesCollection(len(cache.Frames))
+	for _, f := range cache.Frames {
+		if err := frames.Append(f); err != nil {
+			return nil, fmt.Errorf("detector: failed to restore the frames collection from the analysis cache: %w", err)
+		}
+	}
+
+	return frames, nil
+}
+
+// Return a boolean value representing if the analysis stored in the cache was produced with the same analysis-related options
+// as the provided ones. If the options are not matching a message will be stored in the string return value.
+func (cache *AnalysisCache) IsMatching(options DetectorOptions) (bool, string) {
+	if cache.Options.FrameScalingFactor != options.FrameScalingFactor {
+		return false, fmt.Sprintf("the cached analysis was performed with the scaling factor %f", cache.Options.FrameScalingFactor)
+	}
+
+	if cache.Options.Denoise != options.Denoise {
+		return false, fmt.Sprintf("the cached analysis was performed with the denoise option set to %t", cache.Options.Denoise)
+	}
+
+	if !reflect.DeepEqual(cache.Options.IncludeRegions, options.IncludeRegions) || !reflect.DeepEqual(cache.Options.ExcludeRegions, options.ExcludeRegions) {
+		return false, "the cached analysis was performed with different include or exclude regions"
+	}
+
+	if cache.Options.AnalysisStart != options.AnalysisStart || cache.Options.AnalysisEnd != options.AnalysisEnd || cache.Options.FrameStride != options.FrameStride {
+		return false, fmt.Sprintf("the cached analysis was performed on the frames range %q-%q with the frame stride %d", cache.Options.AnalysisStart, cache.Options.AnalysisEnd, cache.Options.FrameStride)
+	}
+
+	if cache.Options.WallClockStart != options.WallClockStart || cache.Options.WallClockTimezone != options.WallClockTimezone {
+		return false, fmt.Sprintf("the cached analysis frames times are based on the wall-clock start %q in the %q time zone", cache.Options.WallClockStart, cache.Options.WallClockTimezone)
+	}
+
+	if cache.Options.SequenceFPS != options.SequenceFPS {
+		return false, fmt.Sprintf("the cached analysis frames timestamps are based on the image sequence frame rate %f", cache.Options.SequenceFPS)
+	}
+
+	if cache.Options.TileColumns != options.TileColumns || cache.Options.TileRows != options.TileRows {
+		return false, fmt.Sprintf("the cached analysis was performed with the %dx%d tiles grid", cache.Options.TileColumns, cache.Options.TileRows)
+	}
+
+	if cache.Options.MotionCompensation != options.MotionCompensation || (options.MotionCompensation && cache.Options.MotionMaxShift != options.MotionMaxShift) {
+		return false, fmt.Sprintf("the cached analysis was performed with the motion compensation set to %t and the maximal shift %d", cache.Options.MotionCompensation, cache.Options.MotionMaxShift)
+	}
+
+	if options.SceneCutDetection && !cache.Options.SceneCutDetection {
+		return false, "the cached analysis was performed without the scene cut detection"
+	}
+
+	if cache.Options.MaskImagePath != options.MaskImagePath {
+		return false, fmt.Sprintf("the cached analysis was performed with the mask image %q", cache.Options.MaskImagePath)
+	}
+
+	return true, ""
+}
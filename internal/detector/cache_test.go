@@ -0,0 +1,102 @@
+package detector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisCacheShouldExportAndImport(t *testing.T) {
+	collection := frame.CreateNewFramesCollection(3)
+	for _, f := range mockFrames(3) {
+		f.Metrics[frame.BrightnessMetricName] = 0.25 * float64(f.OrdinalNumber)
+		assert.Nil(t, collection.Append(f))
+	}
+
+	video := VideoMetadata{Path: "video.mp4", Width: 8, Height: 6, Frames: 3, FPS: 30, Duration: 0.1}
+	options := GetDefaultDetectorOptions()
+
+	buffer := &bytes.Buffer{}
+	err := CreateAnalysisCache(video, options, collection).Export(buffer)
+	assert.Nil(t, err)
+
+	cache, err := ImportAnalysisCache(buffer)
+	assert.Nil(t, err)
+	assert.NotNil(t, cache)
+
+	assert.Equal(t, video, cache.Video)
+	assert.Equal(t, options, cache.Options)
+
+	frames, err := cache.GetFramesCollection()
+	assert.Nil(t, err)
+	assert.Equal(t, collection.GetAll(), frames.GetAll())
+}
+
+func TestAnalysisCacheShouldNotImportUnsupportedVersion(t *testing.T) {
+	cache, err := ImportAnalysisCache(strings.NewReader(`{"version":0,"frames":[{"ordinal-number":1}]}`))
+
+	assert.NotNil(t, err)
+	assert.Nil(t, cache)
+}
+
+func TestAnalysisCacheShouldNotImportEmptyFrames(t *testing.T) {
+	cache, err := ImportAnalysisCache(strings.NewReader(`{"version":2,"frames":[]}`))
+
+	assert.NotNil(t, err)
+	assert.Nil(t, cache)
+}
+
+func TestAnalysisCacheShouldValidateMatchingOptions(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	cache := &AnalysisCache{Version: analysisCacheVersion, Options: options}
+
+	ok, msg := cache.IsMatching(options)
+	assert.True(t, ok)
+	assert.Empty(t, msg)
+
+	options.Denoise = !options.Denoise
+
+	ok, msg = cache.IsMatching(options)
+	assert.False(t, ok)
+	assert.NotEmpty(t, msg)
+
+	options = GetDefaultDetectorOptions()
+	options.SequenceFPS = 25
+
+	ok, msg = cache.IsMatching(options)
+	assert.False(t, ok)
+	assert.NotEmpty(t, msg)
+}
+
+func TestAnalysisCacheShouldNotMatchDifferentTilesGrid(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	cache := &AnalysisCache{Version: analysisCacheVersion, Options: options}
+
+	options.TileColumns = 4
+	options.TileRows = 3
+
+	ok, msg := cache.IsMatching(options)
+	assert.False(t, ok)
+	assert.Contains(t, msg, "1x1")
+}
+
+func TestAnalysisCacheShouldNotMatchMissingSceneChanges(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	cache := &AnalysisCache{Version: analysisCacheVersion, Options: options}
+
+	options.SceneCutDetection = true
+
+	ok, msg := cache.IsMatching(options)
+	assert.False(t, ok)
+	assert.NotEmpty(t, msg)
+
+	cache.Options.SceneCutDetection = true
+	options.SceneCutDetection = false
+
+	ok, msg = cache.IsMatching(options)
+	assert.True(t, ok)
+	assert.Empty(t, msg)
+}
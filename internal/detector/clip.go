@@ -0,0 +1,98 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	vidio "github.com/AlexEidt/Vidio"
+)
+
+// Calculate the range of the clip for the given lightning event represented by the frames ordinal numbers. The range is extended
+// by the pre-roll and post-roll frames and limited to the range of the video frames.
+func getLightningEventClipRange(event LightningEvent, preRollFrames, postRollFrames, videoFrames int) (int, int) {
+	first := event.FirstFrame - preRollFrames
+	if first < 1 {
+		first = 1
+	}
+
+	last := event.LastFrame + postRollFrames
+	if videoFrames > 0 && last > videoFrames {
+		last = videoFrames
+	}
+
+	return first, last
+}
+
+// Helper function used to export a video clip for each lightning event. The clip file name and frames range are stored in the events.
+func (detector *detector) performClipsExport(inputVideoPath, outputDirectoryPath string, events []LightningEvent) error {
+	clipsExportTime := time.Now()
+	detector.renderer.LogDebug("Starting the clips export stage.")
+	detector.renderer.LogInfo("About to export %d clips.", len(events))
+
+	video, err := detector.createFrameSource(inputVideoPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to open the frame source for the clips export stage: %w", err)
+	}
+
+	defer video.Close()
+
+	if video.FPS() <= 0 {
+		return errors.New("detector: the clips export requires the frame rate of the input, which can be specified for the image sequences with the sequence frame rate")
+	}
+
+	if err := os.MkdirAll(outputDirectoryPath, 0770); err != nil {
+		return fmt.Errorf("detector: failed to create the clips output directory: %w", err)
+	}
+
+	progressBarStep, progressBarClose := detector.renderer.Progress("Video clips export stage.", len(events))
+
+	for eventIndex := range events {
+		event := &events[eventIndex]
+
+		first, last := getLightningEventClipRange(*event, int(detector.options.ClipPreRollFrames), int(detector.options.ClipPostRollFrames), video.Frames())
+
+		frameIndexes := make([]int, 0, last-first+1)
+		for ordinalNumber := first; ordinalNumber <= last; ordinalNumber += 1 {
+			frameIndexes = append(frameIndexes, ordinalNumber-1)
+		}
+
+		frames, err := video.ReadFrames(frameIndexes...)
+		if err != nil {
+			return fmt.Errorf("detector: failed to read the clip frames from the video: %w", err)
+		}
+
+		clipName := fmt.Sprintf("clip-%d-%d.mp4", first, last)
+		clipPath := path.Join(outputDirectoryPath, clipName)
+
+		writer, err := vidio.NewVideoWriter(clipPath, video.Width(), video.Height(), &vidio.Options{
+			FPS: video.FPS(),
+		})
+
+		if err != nil {
+			return fmt.Errorf("detector: failed to create the clip video writer: %w", err)
+		}
+
+		for _, frame := range frames {
+			if err := writer.Write(frame.Pix); err != nil {
+				writer.Close()
+				return fmt.Errorf("detector: failed to write the frame to the clip: %w", err)
+			}
+		}
+
+		writer.Close()
+
+		event.ClipPath = clipName
+		event.ClipFirstFrame = first
+		event.ClipLastFrame = last
+
+		progressBarStep()
+		detector.renderer.LogInfo("Event: [%d/%d]. Clip of frames %d-%d exported at: %s", eventIndex+1, len(events), first, last, clipPath)
+	}
+
+	progressBarClose()
+	detector.renderer.LogDebug("Clips export stage finished. Stage took: %s", time.Since(clipsExportTime))
+	return nil
+}
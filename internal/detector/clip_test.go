@@ -0,0 +1,33 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLightningEventClipRangeShouldBeExtendedAndLimited(t *testing.T) {
+	cases := []struct {
+		first         int
+		last          int
+		preRoll       int
+		postRoll      int
+		videoFrames   int
+		expectedFirst int
+		expectedLast  int
+	}{
+		{10, 12, 3, 4, 100, 7, 16},
+		{2, 3, 5, 0, 100, 1, 3},
+		{95, 98, 0, 5, 100, 95, 100},
+		{5, 5, 0, 0, 100, 5, 5},
+	}
+
+	for _, c := range cases {
+		event := LightningEvent{FirstFrame: c.first, LastFrame: c.last}
+
+		first, last := getLightningEventClipRange(event, c.preRoll, c.postRoll, c.videoFrames)
+
+		assert.Equal(t, c.expectedFirst, first)
+		assert.Equal(t, c.expectedLast, last)
+	}
+}
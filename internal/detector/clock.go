@@ -0,0 +1,145 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/source"
+)
+
+const frameImageTimeLayout string = "20060102T150405.000Z0700"
+
+// Structure representing the clock used to assign the presentation timestamps and the wall-clock times to the video frames
+// specified by the ordinal numbers. The wall-clock start is the time of the frame displayed at the start timestamp.
+type framesClock struct {
+	fps            float64
+	startTimestamp float64
+	wallClockStart *time.Time
+}
+
+// Create the frames clock based on the frame rate and the timing metadata of the video.
+func createFramesClock(video VideoMetadata) framesClock {
+	return framesClock{
+		fps:            video.FPS,
+		startTimestamp: video.StartTimestamp,
+		wallClockStart: video.WallClockStart,
+	}
+}
+
+// Return a boolean value representing if the timestamps of the frames are known, which requires the frame rate of the video.
+func (clock framesClock) IsKnown() bool {
+	return clock.fps > 0
+}
+
+// Return the presentation timestamp in seconds of the frame specified by the ordinal number. Zero is returned if the frame
+// rate of the video is unknown.
+func (clock framesClock) GetTimestamp(frameNumber int) float64 {
+	if !clock.IsKnown() {
+		return 0
+	}
+
+	return clock.startTimestamp + float64(frameNumber-1)/clock.fps
+}
+
+// Return the wall-clock time of the frame specified by the ordinal number. Nil is returned if the wall-clock start or the frame
+// rate of the video is unknown.
+func (clock framesClock) GetTime(frameNumber int) *time.Time {
+	if clock.wallClockStart == nil || !clock.IsKnown() {
+		return nil
+	}
+
+	offset := time.Duration(math.Round((clock.GetTimestamp(frameNumber) - clock.startTimestamp) * float64(time.Second)))
+	frameTime := clock.wallClockStart.Add(offset)
+	return &frameTime
+}
+
+// Assign the presentation timestamp and the wall-clock time to the frame based on the frame ordinal number.
+func (clock framesClock) Apply(f *frame.Frame) {
+	f.Timestamp = clock.GetTimestamp(f.OrdinalNumber)
+	f.Time = clock.GetTime(f.OrdinalNumber)
+}
+
+// Return the name of the exported image of the frame specified by the ordinal number with the given extension. The name contains
+// the wall-clock time of the frame if it is known, otherwise the presentation timestamp of the frame if it is known.
+func (clock framesClock) GetFrameImageName(frameNumber int, extension string) string {
+	if frameTime := clock.GetTime(frameNumber); frameTime != nil {
+		return fmt.Sprintf("frame-%d_%s%s", frameNumber, frameTime.Format(frameImageTimeLayout), extension)
+	}
+
+	if clock.IsKnown() {
+		return fmt.Sprintf("frame-%d_%s%s", frameNumber, formatTimestamp(clock.GetTimestamp(frameNumber)), extension)
+	}
+
+	return fmt.Sprintf("frame-%d%s", frameNumber, extension)
+}
+
+// Helper function used to format the timestamp in seconds as hours, minutes and seconds with milliseconds (e.g. 01h02m03.040s),
+// without the characters which are not allowed in the file names.
+func formatTimestamp(seconds float64) string {
+	milliseconds := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%02dh%02dm%02d.%03ds",
+		milliseconds/3600000,
+		milliseconds/60000%60,
+		milliseconds/1000%60,
+		milliseconds%1000)
+}
+
+// Helper function used to parse the wall-clock start specified in the RFC 3339 format or as the date and time without the offset
+// (e.g. "2024-06-01 21:30:05.5"), which is interpreted in the time zone specified by the IANA name.
+func parseWallClockStart(value, timezone string) (time.Time, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("detector: invalid wall-clock time zone %q: %w", timezone, err)
+	}
+
+	if wallClockStart, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return wallClockStart.In(location), nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if wallClockStart, err := time.ParseInLocation(layout, value, location); err == nil {
+			return wallClockStart, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("detector: invalid wall-clock start %q, expected the RFC 3339 time or the \"yyyy-mm-dd hh:mm:ss\" time", value)
+}
+
+// Helper function used to resolve the wall-clock time of the first frame of the video based on the wall-clock options. The creation
+// time of the container is used if specified by the options. Nil is returned if the wall-clock start is not specified.
+func resolveWallClockStart(options DetectorOptions, video source.FrameSource) (*time.Time, error) {
+	if len(options.WallClockStart) == 0 {
+		return nil, nil
+	}
+
+	if options.WallClockStart != CreationTimeWallClockStart {
+		wallClockStart, err := parseWallClockStart(options.WallClockStart, options.WallClockTimezone)
+		if err != nil {
+			return nil, err
+		}
+
+		return &wallClockStart, nil
+	}
+
+	timedVideo, ok := video.(source.TimedFrameSource)
+	if !ok {
+		return nil, errors.New("detector: the creation time is available only for the video files")
+	}
+
+	creationTime, ok := timedVideo.CreationTime()
+	if !ok {
+		return nil, errors.New("detector: the video does not contain the creation time")
+	}
+
+	location, err := time.LoadLocation(options.WallClockTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("detector: invalid wall-clock time zone %q: %w", options.WallClockTimezone, err)
+	}
+
+	creationTime = creationTime.In(location)
+	return &creationTime, nil
+}
@@ -0,0 +1,92 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramesClockShouldAssignTimestampsAndTimes(t *testing.T) {
+	wallClockStart := time.Date(2024, 6, 1, 21, 30, 5, 0, time.UTC)
+	clock := createFramesClock(VideoMetadata{FPS: 25, StartTimestamp: 0.5, WallClockStart: &wallClockStart})
+
+	assert.True(t, clock.IsKnown())
+	assert.Equal(t, 0.5, clock.GetTimestamp(1))
+	assert.InDelta(t, 2.06, clock.GetTimestamp(40), 1e-9)
+	assert.Equal(t, wallClockStart, *clock.GetTime(1))
+	assert.Equal(t, wallClockStart.Add(1560*time.Millisecond), *clock.GetTime(40))
+
+	frames := mockFrames(40)
+	clock.Apply(frames[39])
+	assert.InDelta(t, 2.06, frames[39].Timestamp, 1e-9)
+	assert.Equal(t, wallClockStart.Add(1560*time.Millisecond), *frames[39].Time)
+
+	assert.Equal(t, "frame-40_20240601T213006.560Z.png", clock.GetFrameImageName(40, ".png"))
+}
+
+func TestFramesClockShouldHandleUnknownTimes(t *testing.T) {
+	clock := createFramesClock(VideoMetadata{FPS: 30})
+	assert.Nil(t, clock.GetTime(10))
+	assert.Equal(t, "frame-31_00h00m01.000s.jpg", clock.GetFrameImageName(31, ".jpg"))
+
+	wallClockStart := time.Date(2024, 6, 1, 21, 30, 5, 0, time.UTC)
+	clock = createFramesClock(VideoMetadata{FPS: 0, WallClockStart: &wallClockStart})
+	assert.False(t, clock.IsKnown())
+	assert.Equal(t, 0.0, clock.GetTimestamp(10))
+	assert.Nil(t, clock.GetTime(10))
+	assert.Equal(t, "frame-10.png", clock.GetFrameImageName(10, ".png"))
+}
+
+func TestShouldFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "00h00m00.000s", formatTimestamp(0))
+	assert.Equal(t, "01h02m03.040s", formatTimestamp(3723.04))
+	assert.Equal(t, "00h01m00.000s", formatTimestamp(59.9996))
+}
+
+func TestShouldParseWallClockStart(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Warsaw")
+	assert.Nil(t, err)
+
+	wallClockStart, err := parseWallClockStart("2024-06-01 21:30:05.5", "Europe/Warsaw")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 21, 30, 5, 500000000, location).UnixNano(), wallClockStart.UnixNano())
+	assert.Equal(t, "2024-06-01T21:30:05.5+02:00", wallClockStart.Format(time.RFC3339Nano))
+
+	wallClockStart, err = parseWallClockStart("2024-06-01T19:30:05Z", "Europe/Warsaw")
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-06-01T21:30:05+02:00", wallClockStart.Format(time.RFC3339Nano))
+
+	_, err = parseWallClockStart("yesterday", "UTC")
+	assert.NotNil(t, err)
+
+	_, err = parseWallClockStart("2024-06-01 21:30:05", "Mars/Olympus")
+	assert.NotNil(t, err)
+}
+
+func TestShouldResolveWallClockStart(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+
+	wallClockStart, err := resolveWallClockStart(options, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, wallClockStart)
+
+	options.WallClockStart = "2024-06-01 21:30:05"
+	wallClockStart, err = resolveWallClockStart(options, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 21, 30, 5, 0, time.UTC), *wallClockStart)
+
+	path := filepath.Join(t.TempDir(), "stream.rgba")
+	assert.Nil(t, os.WriteFile(path, make([]byte, 4), 0644))
+
+	video, err := source.CreateRawStreamSource(path, 1, 1, 25)
+	assert.Nil(t, err)
+	defer video.Close()
+
+	options.WallClockStart = CreationTimeWallClockStart
+	_, err = resolveWallClockStart(options, video)
+	assert.NotNil(t, err)
+}
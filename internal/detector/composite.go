@@ -0,0 +1,106 @@
+package detector
+
+import (
+	"fmt"
+	"image"
+	"path"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+// Return the ordinal numbers of the frames required to create the composite image of the given lightning event. The first
+// value is the background frame which is the last frame preceding the event, followed by the detected frames of the event.
+func getLightningEventCompositeFrames(event LightningEvent, detections []int) []int {
+	background := event.FirstFrame - 1
+	if background < 1 {
+		background = event.FirstFrame
+	}
+
+	frames := []int{background}
+	for _, ordinalNumber := range detections {
+		if ordinalNumber >= event.FirstFrame && ordinalNumber <= event.LastFrame && ordinalNumber != background {
+			frames = append(frames, ordinalNumber)
+		}
+	}
+
+	return frames
+}
+
+// Helper function used to blend the detected frames using the lighten blending onto the background frame preceding the first
+// lightning strike. The composite of the whole video and optionally a composite for each event are exported to png files.
+func (detector *detector) performCompositeExport(inputVideoPath, outputDirectoryPath string, detections []int, events []LightningEvent) error {
+	compositeExportTime := time.Now()
+	detector.renderer.LogDebug("Starting the composite export stage.")
+
+	video, err := detector.createFrameSource(inputVideoPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to open the frame source for the composite export stage: %w", err)
+	}
+
+	defer video.Close()
+
+	var (
+		videoComposite *image.RGBA = nil
+		eventComposite *image.RGBA = image.NewRGBA(image.Rect(0, 0, video.Width(), video.Height()))
+	)
+
+	progressBarStep, progressBarClose := detector.renderer.Progress("Video composite export stage.", len(events))
+
+	for eventIndex, event := range events {
+		frameNumbers := getLightningEventCompositeFrames(event, detections)
+
+		frameIndexes := make([]int, 0, len(frameNumbers))
+		for _, ordinalNumber := range frameNumbers {
+			frameIndexes = append(frameIndexes, ordinalNumber-1)
+		}
+
+		frames, err := video.ReadFrames(frameIndexes...)
+		if err != nil {
+			return fmt.Errorf("detector: failed to read the composite frames from the video: %w", err)
+		}
+
+		if videoComposite == nil {
+			videoComposite = image.NewRGBA(image.Rect(0, 0, video.Width(), video.Height()))
+			copy(videoComposite.Pix, frames[0].Pix)
+		}
+
+		copy(eventComposite.Pix, frames[0].Pix)
+
+		for _, frame := range frames[1:] {
+			if err := utils.LightenBlendImage(frame, eventComposite); err != nil {
+				return fmt.Errorf("detector: failed to blend the frame into the event composite: %w", err)
+			}
+
+			if err := utils.LightenBlendImage(frame, videoComposite); err != nil {
+				return fmt.Errorf("detector: failed to blend the frame into the video composite: %w", err)
+			}
+		}
+
+		if detector.options.ExportEventComposites {
+			eventCompositeName := fmt.Sprintf("composite-%d-%d.png", event.FirstFrame, event.LastFrame)
+			eventCompositePath := path.Join(outputDirectoryPath, eventCompositeName)
+			if err := utils.ExportImageAsPng(eventCompositePath, eventComposite); err != nil {
+				return fmt.Errorf("detector: failed to export the event composite image: %w", err)
+			}
+
+			detector.renderer.LogInfo("Event: [%d/%d]. Composite image exported at: %s", eventIndex+1, len(events), eventCompositePath)
+		}
+
+		progressBarStep()
+	}
+
+	progressBarClose()
+
+	if detector.options.ExportComposite {
+		videoCompositePath := path.Join(outputDirectoryPath, "composite.png")
+		if err := utils.ExportImageAsPng(videoCompositePath, videoComposite); err != nil {
+			return fmt.Errorf("detector: failed to export the video composite image: %w", err)
+		}
+
+		detector.renderer.LogInfo("Composite image exported at: %s", videoCompositePath)
+	}
+
+	detector.renderer.LogDebug("Composite export stage finished. Stage took: %s", time.Since(compositeExportTime))
+	return nil
+}
@@ -0,0 +1,25 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLightningEventCompositeFramesShouldStartWithBackgroundFrame(t *testing.T) {
+	cases := []struct {
+		event      LightningEvent
+		detections []int
+		expected   []int
+	}{
+		{LightningEvent{FirstFrame: 5, LastFrame: 8}, []int{2, 5, 6, 8, 12}, []int{4, 5, 6, 8}},
+		{LightningEvent{FirstFrame: 1, LastFrame: 2}, []int{1, 2}, []int{1, 2}},
+		{LightningEvent{FirstFrame: 3, LastFrame: 3}, []int{3}, []int{2, 3}},
+	}
+
+	for _, c := range cases {
+		actual := getLightningEventCompositeFrames(c.event, c.detections)
+
+		assert.Equal(t, c.expected, actual)
+	}
+}
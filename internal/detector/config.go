@@ -0,0 +1,218 @@
+package detector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
+const (
+	OptionsFileName string = "options.json"
+
+	configOptionsKey            string = "options"
+	configPresetsKey            string = "presets"
+	configExplicitThresholdsKey string = "explicit-thresholds"
+	configMetricThresholdsKey   string = "metric-thresholds"
+)
+
+// Structure representing the detector configuration file. The base options and the named presets are stored as the document
+// nodes, so only the values specified in the file are overriding the options they are applied to.
+type DetectorConfig struct {
+	Options yaml.Node            `yaml:"options"`
+	Presets map[string]yaml.Node `yaml:"presets"`
+}
+
+// Read and decode the detector configuration from the YAML or JSON file specified by the path.
+func LoadDetectorConfig(path string) (*DetectorConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("detector: failed to open the config file: %w", err)
+	}
+
+	defer file.Close()
+
+	return ImportDetectorConfig(file)
+}
+
+// Decode the detector configuration from the provided reader which can be a file reference. The document is either a mapping
+// with the "options" and "presets" keys or a flat mapping of the options, such as the exported options file. The options keys
+// are the same as the keys of the options in the JSON reports.
+func ImportDetectorConfig(file io.Reader) (*DetectorConfig, error) {
+	document := yaml.Node{}
+	if err := yaml.NewDecoder(file).Decode(&document); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("detector: failed to decode the config: %w", err)
+	}
+
+	config := &DetectorConfig{
+		Presets: make(map[string]yaml.Node),
+	}
+
+	if document.Kind == 0 {
+		return config, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("detector: the config must be a mapping, found a %s at line %d", describeNodeKind(root), root.Line)
+	}
+
+	if !hasMappingKey(root, configOptionsKey) && !hasMappingKey(root, configPresetsKey) {
+		config.Options = *root
+	} else if err := decodeStrict(root, config); err != nil {
+		return nil, fmt.Errorf("detector: failed to decode the config: %w", err)
+	}
+
+	if err := decodeStrict(&config.Options, &DetectorOptions{}); err != nil {
+		return nil, fmt.Errorf("detector: invalid config options: %w", err)
+	}
+
+	for name, preset := range config.Presets {
+		if err := decodeStrict(&preset, &DetectorOptions{}); err != nil {
+			return nil, fmt.Errorf("detector: invalid config preset %q options: %w", name, err)
+		}
+	}
+
+	return config, nil
+}
+
+// Return the names of the presets specified in the config in ascending order.
+func (config *DetectorConfig) GetPresetsNames() []string {
+	names := make([]string, 0, len(config.Presets))
+	for name := range config.Presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Override the provided options with the base options of the config and then with the options of the preset specified by
+// the name. The preset is not applied if the name is empty. The options that are not specified in the config are not changed.
+// The detection thresholds specified in the config are marked as explicit, unless the explicit thresholds are listed directly.
+func (config *DetectorConfig) Apply(options *DetectorOptions, preset string) error {
+	if err := applyOptionsNode(&config.Options, options); err != nil {
+		return fmt.Errorf("detector: failed to apply the config options: %w", err)
+	}
+
+	if len(preset) == 0 {
+		return nil
+	}
+
+	presetOptions, ok := config.Presets[preset]
+	if !ok {
+		return fmt.Errorf("detector: the config does not contain the %q preset (available: %s)", preset, strings.Join(config.GetPresetsNames(), ", "))
+	}
+
+	if err := applyOptionsNode(&presetOptions, options); err != nil {
+		return fmt.Errorf("detector: failed to apply the config preset %q options: %w", preset, err)
+	}
+
+	return nil
+}
+
+// Write the JSON format options to the provided writer which can be a file reference. The exported options can be used as
+// the config file.
+func (options *DetectorOptions) Export(file io.Writer) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(options); err != nil {
+		return fmt.Errorf("detector: failed to encode the options: %w", err)
+	}
+
+	return nil
+}
+
+// Helper function used to override the options with the values specified by the node and mark the specified thresholds as explicit.
+func applyOptionsNode(node *yaml.Node, options *DetectorOptions) error {
+	// NOTE: The decoder is merging the mappings into the existing maps.
+	cloneOptions(options)
+
+	if err := decodeStrict(node, options); err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.MappingNode || hasMappingKey(node, configExplicitThresholdsKey) {
+		return nil
+	}
+
+	thresholds := getMappingValue(node, configMetricThresholdsKey)
+	if thresholds == nil || thresholds.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		if hasMappingKey(thresholds, name) {
+			options.SetMetricThresholdExplicit(name)
+		}
+	}
+
+	return nil
+}
+
+// Helper function used to decode the node into the target value and fail on the keys not matching any of the target fields.
+func decodeStrict(node *yaml.Node, target interface{}) error {
+	if node.Kind == 0 {
+		return nil
+	}
+
+	buffer, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(buffer))
+	decoder.KnownFields(true)
+
+	return decoder.Decode(target)
+}
+
+func copyMetricsValues(values map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(values))
+	for name, value := range values {
+		result[name] = value
+	}
+
+	return result
+}
+
+func hasMappingKey(node *yaml.Node, key string) bool {
+	for index := 0; index < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			return node.Content[index+1]
+		}
+	}
+
+	return nil
+}
+
+func describeNodeKind(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.ScalarNode:
+		return "scalar"
+	case yaml.AliasNode:
+		return "alias"
+	default:
+		return "document"
+	}
+}
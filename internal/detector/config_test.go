@@ -0,0 +1,144 @@
+package detector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigShouldApplyBaseOptionsAndPreset(t *testing.T) {
+	content := `
+options:
+  moving-mean-resolution: 30
+  metric-thresholds:
+    brightness: 0.1
+presets:
+  night-storm:
+    auto-thresholds: true
+    denoise: true
+    metric-thresholds:
+      brightness: 0.05
+  daylight:
+    exclude-regions:
+      - "0,0,100,50"
+`
+
+	config, err := ImportDetectorConfig(strings.NewReader(content))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"daylight", "night-storm"}, config.GetPresetsNames())
+
+	options := GetDefaultDetectorOptions()
+	defaultOptions := GetDefaultDetectorOptions()
+
+	assert.Nil(t, config.Apply(&options, "night-storm"))
+	assert.Equal(t, int32(30), options.MovingMeanResolution)
+	assert.Equal(t, 0.05, options.GetMetricThreshold(frame.BrightnessMetricName))
+	assert.True(t, options.AutoThresholds)
+	assert.True(t, options.Denoise)
+	assert.Equal(t, defaultOptions.GetMetricThreshold(frame.ColorDifferenceMetricName), options.GetMetricThreshold(frame.ColorDifferenceMetricName))
+	assert.Empty(t, options.ExcludeRegions)
+
+	options = GetDefaultDetectorOptions()
+	assert.Nil(t, config.Apply(&options, ""))
+	assert.Equal(t, 0.1, options.GetMetricThreshold(frame.BrightnessMetricName))
+	assert.False(t, options.AutoThresholds)
+}
+
+func TestConfigShouldImportFlatJsonOptions(t *testing.T) {
+	expected := GetDefaultDetectorOptions()
+	expected.MovingMeanResolution = 75
+	expected.IncludeRegions = []string{"10,10,20,20"}
+
+	buffer := bytes.Buffer{}
+	assert.Nil(t, expected.Export(&buffer))
+
+	config, err := ImportDetectorConfig(&buffer)
+	assert.Nil(t, err)
+
+	options := GetDefaultDetectorOptions()
+	assert.Nil(t, config.Apply(&options, ""))
+	assert.Equal(t, expected, options)
+}
+
+func TestConfigShouldNotImportUnknownOptions(t *testing.T) {
+	cases := []string{
+		"brightness-treshold: 0.1",
+		"options:\n  brightness-treshold: 0.1",
+		"presets:\n  dashcam:\n    brightness-treshold: 0.1",
+		"- 0.1",
+	}
+
+	for _, content := range cases {
+		config, err := ImportDetectorConfig(strings.NewReader(content))
+		assert.Nil(t, config)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestConfigShouldNotApplyMissingPreset(t *testing.T) {
+	config, err := ImportDetectorConfig(strings.NewReader("presets:\n  daylight:\n    denoise: true"))
+	assert.Nil(t, err)
+
+	options := GetDefaultDetectorOptions()
+	err = config.Apply(&options, "night-storm")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "daylight")
+}
+
+func TestConfigShouldImportEmptyDocument(t *testing.T) {
+	config, err := ImportDetectorConfig(strings.NewReader(""))
+	assert.Nil(t, err)
+
+	options := GetDefaultDetectorOptions()
+	assert.Nil(t, config.Apply(&options, ""))
+	assert.Equal(t, GetDefaultDetectorOptions(), options)
+}
+
+func TestConfigShouldMergeMetricWeights(t *testing.T) {
+	config, err := ImportDetectorConfig(strings.NewReader("metric-weights:\n  brightness: 2.5"))
+	assert.Nil(t, err)
+
+	defaultOptions := GetDefaultDetectorOptions()
+	options := defaultOptions
+
+	assert.Nil(t, config.Apply(&options, ""))
+	assert.Equal(t, 2.5, options.GetMetricWeight(frame.BrightnessMetricName))
+	assert.Equal(t, 1.0, options.GetMetricWeight(frame.ColorDifferenceMetricName))
+	assert.Equal(t, 1.0, defaultOptions.GetMetricWeight(frame.BrightnessMetricName))
+}
+
+func TestConfigShouldMarkSpecifiedThresholdsAsExplicit(t *testing.T) {
+	content := `
+options:
+  metric-thresholds:
+    brightness: 0.1
+presets:
+  daylight:
+    metric-thresholds:
+      color-difference: 0.05
+`
+
+	config, err := ImportDetectorConfig(strings.NewReader(content))
+	assert.Nil(t, err)
+
+	options := GetDefaultDetectorOptions()
+	assert.Nil(t, config.Apply(&options, "daylight"))
+	assert.Equal(t, []string{frame.BrightnessMetricName, frame.ColorDifferenceMetricName}, options.ExplicitThresholds)
+
+	exported := GetDefaultDetectorOptions()
+	exported.AutoThresholds = true
+	exported.SetMetricThresholdExplicit(frame.BinaryThresholdDifferenceMetricName)
+
+	buffer := bytes.Buffer{}
+	assert.Nil(t, exported.Export(&buffer))
+
+	config, err = ImportDetectorConfig(&buffer)
+	assert.Nil(t, err)
+
+	options = GetDefaultDetectorOptions()
+	assert.Nil(t, config.Apply(&options, ""))
+	assert.Equal(t, []string{frame.BinaryThresholdDifferenceMetricName}, options.ExplicitThresholds)
+}
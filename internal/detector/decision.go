@@ -0,0 +1,193 @@
+package detector
+
+import (
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
+// Structure representing the detection requirement check of a single frame metric.
+type MetricDecision struct {
+	Value            float64 `json:"value"`
+	Baseline         float64 `json:"baseline"`
+	Deviation        float64 `json:"deviation"`
+	Threshold        float64 `json:"threshold"`
+	Limit            float64 `json:"limit"`
+	Margin           float64 `json:"margin"`
+	NormalizedMargin float64 `json:"normalized-margin"`
+	Passed           bool    `json:"passed"`
+}
+
+// Structure representing the detection decision of a single frame, scored with the weighted mean of the normalized margins.
+type FrameDecision struct {
+	Metrics     map[string]MetricDecision `json:"metrics"`
+	Passed      int                       `json:"passed"`
+	Score       float64                   `json:"score"`
+	Detected    bool                      `json:"detected"`
+	Sustainable bool                      `json:"sustainable"`
+	Sustained   bool                      `json:"sustained"`
+	Tile        int                       `json:"tile,omitempty"`
+	SceneCut    bool                      `json:"scene-cut,omitempty"`
+}
+
+// Accessor of the moving baseline and the moving standard deviation of the metric specified by the name for the checked frame.
+type movingStatistics func(name string) (baseline, deviation float64)
+
+// Helper function used to check the detection requirements of the frame and, if the hysteresis is enabled, the sustain requirements.
+func decideFrame(options DetectorOptions, f *frame.Frame, statistics movingStatistics) FrameDecision {
+	detectionThresholds := func(name string) float64 {
+		if options.DetectionMode == ZScoreDetectionMode {
+			return options.ZScoreSigma
+		}
+
+		return options.GetMetricThreshold(name)
+	}
+
+	decision := combineFrameDecisions(options, f, statistics, detectionThresholds)
+
+	if options.IsHysteresisEnabled() {
+		sustainDecision := combineFrameDecisions(options, f, statistics, func(name string) float64 {
+			if threshold, ok := options.GetMetricSustainThreshold(name); ok {
+				return threshold
+			}
+
+			return detectionThresholds(name)
+		})
+
+		decision.Sustainable = decision.Detected || sustainDecision.Detected
+	}
+
+	return decision
+}
+
+// Helper function used to check the requirements of the whole frame and of each tile, any of which detects the frame.
+func combineFrameDecisions(options DetectorOptions, f *frame.Frame, statistics movingStatistics, thresholds func(name string) float64) FrameDecision {
+	decision := combineMetricsDecisions(options, f, 0, statistics, thresholds)
+
+	tileScore := 0.0
+	for tile := 1; tile <= f.Tiles; tile += 1 {
+		tileDecision := combineMetricsDecisions(options, f, tile, statistics, thresholds)
+		if tileDecision.Detected && (decision.Tile == 0 || tileDecision.Score > tileScore) {
+			decision.Tile = tile
+			tileScore = tileDecision.Score
+		}
+	}
+
+	decision.Detected = decision.Detected || decision.Tile != 0
+	return decision
+}
+
+// Helper function used to check the requirements of the metrics of the frame, or of the tile if not zero, and combine the results.
+func combineMetricsDecisions(options DetectorOptions, f *frame.Frame, tile int, statistics movingStatistics, thresholds func(name string) float64) FrameDecision {
+	names := frame.GetMetricsNames()
+	decision := FrameDecision{
+		Metrics: make(map[string]MetricDecision, len(names)),
+	}
+
+	weightsSum := 0.0
+	for _, name := range names {
+		metricName := name
+		if tile != 0 {
+			metricName = frame.GetTileMetricName(tile, name)
+		}
+
+		baseline, deviation := statistics(metricName)
+
+		metricDecision := MetricDecision{
+			Value:     f.GetMetricValue(metricName),
+			Baseline:  baseline,
+			Deviation: deviation,
+			Threshold: thresholds(name),
+		}
+
+		if options.DetectionMode == ZScoreDetectionMode {
+			// NOTE: The comparison is performed without the division, so the frames of the sections without any variation
+			// are not detected.
+			metricDecision.Limit = baseline + metricDecision.Threshold*deviation
+			metricDecision.Margin = metricDecision.Value - metricDecision.Limit
+			metricDecision.Passed = metricDecision.Margin > 0
+		} else {
+			metricDecision.Limit = baseline + metricDecision.Threshold
+			metricDecision.Margin = metricDecision.Value - metricDecision.Limit
+			metricDecision.Passed = metricDecision.Value >= metricDecision.Limit
+		}
+
+		metricDecision.NormalizedMargin = normalizeMargin(options, metricDecision)
+
+		if metricDecision.Passed {
+			decision.Passed += 1
+		}
+
+		weight := options.GetMetricWeight(name)
+		decision.Score += weight * metricDecision.NormalizedMargin
+		weightsSum += weight
+
+		decision.Metrics[name] = metricDecision
+	}
+
+	if weightsSum != 0 {
+		decision.Score /= weightsSum
+	}
+
+	switch options.CombinationRule {
+	case AnyCombinationRule:
+		decision.Detected = decision.Passed >= 1
+	case KOfNCombinationRule:
+		decision.Detected = decision.Passed >= int(options.CombinationMinimumMetrics)
+	case WeightedCombinationRule:
+		decision.Detected = decision.Score >= options.CombinationScoreThreshold
+	default:
+		decision.Detected = decision.Passed == len(names)
+	}
+
+	return decision
+}
+
+// Helper function used to divide the margin by the threshold, or by the moving standard deviation in the z-score detection mode.
+func normalizeMargin(options DetectorOptions, metricDecision MetricDecision) float64 {
+	scale := metricDecision.Threshold
+	if options.DetectionMode == ZScoreDetectionMode || scale <= 0 {
+		scale = metricDecision.Deviation
+	}
+
+	if scale <= 0 {
+		return metricDecision.Margin
+	}
+
+	return metricDecision.Margin / scale
+}
+
+// Helper function used to exclude the frame classified as the scene cut from the detections if the scene cut detection is enabled.
+func applySceneCut(options DetectorOptions, f *frame.Frame, decision *FrameDecision, precedingDetected bool) {
+	if !options.SceneCutDetection || precedingDetected || !(decision.Detected || decision.Sustainable) {
+		return
+	}
+
+	if f.SceneChange >= options.SceneCutThreshold {
+		decision.Detected = false
+		decision.Sustainable = false
+		decision.SceneCut = true
+	}
+}
+
+// Structure representing the state of the hysteresis detection.
+type hysteresisState struct {
+	active bool
+}
+
+// Apply the hysteresis to the decision of the next frame and return a boolean value representing if the frame is detected.
+func (state *hysteresisState) Apply(decision *FrameDecision) bool {
+	decision.Sustained = !decision.Detected && state.active && decision.Sustainable
+	state.active = decision.Detected || decision.Sustained
+	return state.active
+}
+
+// Return the names of the metrics which requirements are not met in the order of the metrics.
+func (decision FrameDecision) GetFailedMetricsNames() []string {
+	names := make([]string, 0)
+	for _, name := range frame.GetMetricsNames() {
+		if metricDecision, ok := decision.Metrics[name]; ok && !metricDecision.Passed {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
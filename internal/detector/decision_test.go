@@ -0,0 +1,324 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameDecisionShouldCombineMetricsUsingRule(t *testing.T) {
+	// NOTE: The brightness and the color difference requirements are met and the binary threshold difference is not.
+	f := &frame.Frame{
+		OrdinalNumber: 1,
+		Metrics: map[string]float64{
+			frame.BrightnessMetricName:                0.5,
+			frame.ColorDifferenceMetricName:           0.4,
+			frame.BinaryThresholdDifferenceMetricName: 0.0,
+		},
+	}
+
+	statistics := func(name string) (float64, float64) {
+		return 0.1, 0.05
+	}
+
+	cases := []struct {
+		rule           string
+		minimum        int32
+		weights        map[string]float64
+		scoreThreshold float64
+		expected       bool
+	}{
+		{AllCombinationRule, 2, nil, 0.0, false},
+		{AnyCombinationRule, 2, nil, 0.0, true},
+		{KOfNCombinationRule, 2, nil, 0.0, true},
+		{KOfNCombinationRule, 3, nil, 0.0, false},
+		{WeightedCombinationRule, 2, nil, 0.0, true},
+		{WeightedCombinationRule, 2, nil, 1.5, false},
+		{WeightedCombinationRule, 2, map[string]float64{frame.BinaryThresholdDifferenceMetricName: 10.0}, 0.0, false},
+		{WeightedCombinationRule, 2, map[string]float64{frame.BinaryThresholdDifferenceMetricName: 0.0}, 1.5, true},
+	}
+
+	for _, c := range cases {
+		options := GetDefaultDetectorOptions()
+		options.CombinationRule = c.rule
+		options.CombinationMinimumMetrics = c.minimum
+		options.CombinationScoreThreshold = c.scoreThreshold
+		for name, weight := range c.weights {
+			options.SetMetricWeight(name, weight)
+		}
+
+		for _, name := range frame.GetMetricsNames() {
+			options.SetMetricThreshold(name, 0.1)
+		}
+
+		decision := decideFrame(options, f, statistics)
+
+		assert.Equal(t, c.expected, decision.Detected, "rule: %s minimum: %d weights: %v", c.rule, c.minimum, c.weights)
+		assert.Equal(t, 2, decision.Passed)
+		assert.Equal(t, []string{frame.BinaryThresholdDifferenceMetricName}, decision.GetFailedMetricsNames())
+	}
+}
+
+func TestFrameDecisionShouldCalculateMetricsMargins(t *testing.T) {
+	f := &frame.Frame{
+		OrdinalNumber: 1,
+		Metrics: map[string]float64{
+			frame.BrightnessMetricName:                0.5,
+			frame.ColorDifferenceMetricName:           0.3,
+			frame.BinaryThresholdDifferenceMetricName: 0.1,
+		},
+	}
+
+	statistics := func(name string) (float64, float64) {
+		return 0.1, 0.05
+	}
+
+	options := GetDefaultDetectorOptions()
+	for _, name := range frame.GetMetricsNames() {
+		options.SetMetricThreshold(name, 0.1)
+	}
+
+	const delta float64 = 1e-9
+
+	decision := decideFrame(options, f, statistics)
+	assert.InDelta(t, 0.3, decision.Metrics[frame.BrightnessMetricName].Margin, delta)
+	assert.InDelta(t, 0.1, decision.Metrics[frame.ColorDifferenceMetricName].Margin, delta)
+	assert.InDelta(t, -0.1, decision.Metrics[frame.BinaryThresholdDifferenceMetricName].Margin, delta)
+	assert.InDelta(t, 3.0, decision.Metrics[frame.BrightnessMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, 1.0, decision.Score, delta)
+	assert.InDelta(t, 0.2, decision.Metrics[frame.BrightnessMetricName].Limit, delta)
+
+	options.DetectionMode = ZScoreDetectionMode
+	options.ZScoreSigma = 2
+
+	decision = decideFrame(options, f, statistics)
+	assert.InDelta(t, 0.3, decision.Metrics[frame.BrightnessMetricName].Margin, delta)
+	assert.InDelta(t, 0.2, decision.Metrics[frame.BrightnessMetricName].Limit, delta)
+	assert.InDelta(t, 2.0, decision.Metrics[frame.BrightnessMetricName].Threshold, delta)
+	assert.InDelta(t, 6.0, decision.Metrics[frame.BrightnessMetricName].NormalizedMargin, delta)
+	assert.False(t, decision.Metrics[frame.BinaryThresholdDifferenceMetricName].Passed)
+	assert.False(t, decision.Detected)
+}
+
+func TestFrameDecisionShouldScoreNormalizedMargins(t *testing.T) {
+	f := &frame.Frame{
+		OrdinalNumber: 1,
+		Metrics: map[string]float64{
+			frame.BrightnessMetricName:                0.3,
+			frame.ColorDifferenceMetricName:           0.02,
+			frame.BinaryThresholdDifferenceMetricName: 0.004,
+		},
+	}
+
+	deviations := map[string]float64{
+		frame.BrightnessMetricName:                0.1,
+		frame.ColorDifferenceMetricName:           0.01,
+		frame.BinaryThresholdDifferenceMetricName: 0.002,
+	}
+
+	statistics := func(name string) (float64, float64) {
+		return 0.0, deviations[name]
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.CombinationRule = WeightedCombinationRule
+	options.SetMetricThreshold(frame.BrightnessMetricName, 0.3)
+	options.SetMetricThreshold(frame.ColorDifferenceMetricName, 0.02)
+	options.SetMetricThreshold(frame.BinaryThresholdDifferenceMetricName, 0.004)
+
+	const delta float64 = 1e-9
+
+	// NOTE: Each metric is exactly at its limit, so the score is zero regardless of the metrics scales.
+	decision := decideFrame(options, f, statistics)
+	assert.InDelta(t, 0.0, decision.Score, delta)
+	assert.True(t, decision.Detected)
+
+	options.SetMetricThreshold(frame.BrightnessMetricName, 0.15)
+	options.SetMetricThreshold(frame.ColorDifferenceMetricName, 0.04)
+	options.SetMetricThreshold(frame.BinaryThresholdDifferenceMetricName, 0.0)
+
+	decision = decideFrame(options, f, statistics)
+	assert.InDelta(t, 1.0, decision.Metrics[frame.BrightnessMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, -0.5, decision.Metrics[frame.ColorDifferenceMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, 2.0, decision.Metrics[frame.BinaryThresholdDifferenceMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, 2.5/3, decision.Score, delta)
+
+	options.DetectionMode = ZScoreDetectionMode
+	options.ZScoreSigma = 2
+
+	decision = decideFrame(options, f, statistics)
+	assert.InDelta(t, 1.0, decision.Metrics[frame.BrightnessMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, 0.0, decision.Metrics[frame.ColorDifferenceMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, 0.0, decision.Metrics[frame.BinaryThresholdDifferenceMetricName].NormalizedMargin, delta)
+	assert.InDelta(t, 1.0/3, decision.Score, delta)
+}
+
+func TestFrameDecisionShouldCheckSustainThresholds(t *testing.T) {
+	f := &frame.Frame{
+		OrdinalNumber: 1,
+		Metrics: map[string]float64{
+			frame.BrightnessMetricName:                0.25,
+			frame.ColorDifferenceMetricName:           0.25,
+			frame.BinaryThresholdDifferenceMetricName: 0.25,
+		},
+	}
+
+	statistics := func(name string) (float64, float64) {
+		return 0.1, 0.05
+	}
+
+	options := GetDefaultDetectorOptions()
+	for _, name := range frame.GetMetricsNames() {
+		options.SetMetricThreshold(name, 0.2)
+	}
+
+	decision := decideFrame(options, f, statistics)
+	assert.False(t, decision.Detected)
+	assert.False(t, decision.Sustainable)
+
+	options.SetMetricSustainThreshold(frame.BrightnessMetricName, 0.1)
+	options.SetMetricSustainThreshold(frame.ColorDifferenceMetricName, 0.1)
+
+	decision = decideFrame(options, f, statistics)
+	assert.False(t, decision.Detected)
+	assert.False(t, decision.Sustainable)
+	assert.InDelta(t, 0.2, decision.Metrics[frame.BrightnessMetricName].Threshold, 1e-9)
+
+	options.SetMetricSustainThreshold(frame.BinaryThresholdDifferenceMetricName, 0.1)
+
+	decision = decideFrame(options, f, statistics)
+	assert.False(t, decision.Detected)
+	assert.True(t, decision.Sustainable)
+
+	options.DetectionMode = ZScoreDetectionMode
+	options.SustainThresholds = map[string]float64{frame.BrightnessMetricName: 2.0}
+	options.CombinationRule = AnyCombinationRule
+
+	decision = decideFrame(options, f, statistics)
+	assert.False(t, decision.Detected)
+	assert.True(t, decision.Sustainable)
+}
+
+func TestHysteresisShouldSustainDetectionsFollowingDetectedFrame(t *testing.T) {
+	cases := []struct {
+		decisions string
+		expected  string
+	}{
+		{"..x...", "..x..."},
+		{"..xss.", "..xxx."},
+		{".ss.ss", "......"},
+		{"xs.sx.", "xx..x."},
+		{"xsxs.s", "xxxx.."},
+	}
+
+	for _, c := range cases {
+		state := &hysteresisState{}
+
+		actual := ""
+		for _, symbol := range c.decisions {
+			decision := FrameDecision{
+				Detected:    symbol == 'x',
+				Sustainable: symbol == 'x' || symbol == 's',
+			}
+
+			if state.Apply(&decision) {
+				actual += "x"
+			} else {
+				actual += "."
+			}
+
+			assert.Equal(t, symbol == 's' && state.active, decision.Sustained)
+		}
+
+		assert.Equal(t, c.expected, actual, "decisions: %s", c.decisions)
+	}
+}
+
+func TestFrameDecisionShouldDetectFrameByTile(t *testing.T) {
+	// NOTE: The whole frame values are diluted and only the second tile exceeds its own moving mean.
+	f := &frame.Frame{
+		OrdinalNumber: 1,
+		Metrics:       map[string]float64{},
+		Tiles:         3,
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		f.Metrics[name] = 0.15
+		f.Metrics[frame.GetTileMetricName(1, name)] = 0.1
+		f.Metrics[frame.GetTileMetricName(2, name)] = 0.4
+		f.Metrics[frame.GetTileMetricName(3, name)] = 0.3
+	}
+
+	statistics := func(name string) (float64, float64) {
+		if name == frame.GetTileMetricName(3, frame.BrightnessMetricName) {
+			return 0.25, 0.05
+		}
+
+		return 0.1, 0.05
+	}
+
+	options := GetDefaultDetectorOptions()
+	for _, name := range frame.GetMetricsNames() {
+		options.SetMetricThreshold(name, 0.1)
+	}
+
+	decision := decideFrame(options, f, statistics)
+
+	assert.True(t, decision.Detected)
+	assert.Equal(t, 2, decision.Tile)
+	assert.Equal(t, 0, decision.Passed)
+	assert.Equal(t, 0.15, decision.Metrics[frame.BrightnessMetricName].Value)
+
+	f.Tiles = 0
+	decision = decideFrame(options, f, statistics)
+
+	assert.False(t, decision.Detected)
+	assert.Equal(t, 0, decision.Tile)
+}
+
+func TestFrameDecisionShouldNotDetectSceneCut(t *testing.T) {
+	f := &frame.Frame{
+		OrdinalNumber: 2,
+		SceneChange:   0.8,
+		Metrics: map[string]float64{
+			frame.BrightnessMetricName:                0.5,
+			frame.ColorDifferenceMetricName:           0.4,
+			frame.BinaryThresholdDifferenceMetricName: 0.3,
+		},
+	}
+
+	statistics := func(name string) (float64, float64) {
+		return 0.1, 0.05
+	}
+
+	options := GetDefaultDetectorOptions()
+	for _, name := range frame.GetMetricsNames() {
+		options.SetMetricThreshold(name, 0.1)
+	}
+
+	decision := decideFrame(options, f, statistics)
+	applySceneCut(options, f, &decision, false)
+	assert.True(t, decision.Detected)
+	assert.False(t, decision.SceneCut)
+
+	options.SceneCutDetection = true
+
+	decision = decideFrame(options, f, statistics)
+	applySceneCut(options, f, &decision, true)
+	assert.True(t, decision.Detected)
+	assert.False(t, decision.SceneCut)
+
+	decision = decideFrame(options, f, statistics)
+	applySceneCut(options, f, &decision, false)
+	assert.False(t, decision.Detected)
+	assert.False(t, decision.Sustainable)
+	assert.True(t, decision.SceneCut)
+
+	f.SceneChange = 0.1
+
+	decision = decideFrame(options, f, statistics)
+	applySceneCut(options, f, &decision, false)
+	assert.True(t, decision.Detected)
+	assert.False(t, decision.SceneCut)
+}
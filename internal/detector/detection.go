@@ -1,9 +1,10 @@
 package detector
 
-import "github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+import "fmt"
 
 const (
-	candidatesBufferSize int = 4
+	defaultCandidatesBufferSize int = 4
+	defaultMaxFilledGap         int = 2
 )
 
 // A data structure that stores the detections and allows for automatic correction of missed detections thanks to a candidate buffer.
@@ -11,13 +12,32 @@ type DetectionBuffer interface {
 	// Insert new a frame represented by the index and the detection status.
 	Append(index int, detected bool)
 
+	// Mark the end of the appended frames, so the detections awaiting the following frames are resolved.
+	Close()
+
 	// Convert the detections collection into a ascending sorted slice of frames indexes.
 	Resolve() []int
+
+	// Return the ascending sorted slice of frames indexes of the detections resolved since the previous call. The resolved
+	// detections are final and will not be affected by further appended frames.
+	ResolveLatest() []int
+
+	// Return the number of appended frames, including the frame itself, after which the detection of the frame is final.
+	Latency() int
 }
 
 type detectionBuffer struct {
 	detectionsBuffer []detectionBufferElement
 	candidatesBuffer []detectionBufferElement
+	resolvedCount    int
+	candidatesSize   int
+	maxGap           int
+	minRunLength     int
+	maxRunLength     int
+	lastCandidate    int
+	runFirst         int
+	runLast          int
+	runResolved      int
 }
 
 type detectionBufferElement struct {
@@ -25,16 +45,51 @@ type detectionBufferElement struct {
 	detected bool
 }
 
-// Create a new detection buffer instance with the candidate buffer size set to four.
+// Create a new detection buffer instance with the candidate buffer size set to four, filling the gaps of up to two not detected
+// frames and without the detections runs length limits.
 func CreateDetectionBuffer() DetectionBuffer {
+	return CreateConfiguredDetectionBuffer(defaultCandidatesBufferSize, defaultMaxFilledGap, 1, 0)
+}
+
+// Create a new detection buffer instance with the given candidate buffer size. The not detected frames between two detected
+// frames are treated as detected if both detected frames are in the candidate buffer and the gap is not longer than the maximal
+// gap. The runs of consecutive detections shorter than the minimal run length or longer than the maximal run length are
+// dropped. The maximal run length is not limited if set to zero. Panic if the parameters are invalid.
+func CreateConfiguredDetectionBuffer(candidatesSize, maxGap, minRunLength, maxRunLength int) DetectionBuffer {
+	if candidatesSize < 2 || maxGap < 0 || maxGap > candidatesSize-2 {
+		panic(fmt.Sprintf("detector: invalid detection buffer candidates size %d and maximal gap %d", candidatesSize, maxGap))
+	}
+
+	if minRunLength < 1 || (maxRunLength != 0 && maxRunLength < minRunLength) {
+		panic(fmt.Sprintf("detector: invalid detection buffer minimal run length %d and maximal run length %d", minRunLength, maxRunLength))
+	}
+
 	return &detectionBuffer{
 		detectionsBuffer: make([]detectionBufferElement, 0),
-		candidatesBuffer: make([]detectionBufferElement, 0, candidatesBufferSize),
+		candidatesBuffer: make([]detectionBufferElement, 0, candidatesSize),
+		resolvedCount:    0,
+		candidatesSize:   candidatesSize,
+		maxGap:           maxGap,
+		minRunLength:     minRunLength,
+		maxRunLength:     maxRunLength,
+		lastCandidate:    -1,
+		runFirst:         -1,
+		runLast:          -1,
+		runResolved:      -1,
 	}
 }
 
+// Helper function used to create the detection buffer configured by the detector options.
+func createDetectionBuffer(options DetectorOptions) DetectionBuffer {
+	return CreateConfiguredDetectionBuffer(
+		int(options.DetectionWindowLength),
+		int(options.DetectionMaxGap),
+		int(options.DetectionMinRunLength),
+		int(options.DetectionMaxRunLength))
+}
+
 func (detection *detectionBuffer) Append(index int, detected bool) {
-	if len(detection.candidatesBuffer) < candidatesBufferSize {
+	if len(detection.candidatesBuffer) < detection.candidatesSize {
 		detection.candidatesBuffer = append(detection.candidatesBuffer, detectionBufferElement{
 			index:    index,
 			detected: detected,
@@ -46,54 +101,93 @@ func (detection *detectionBuffer) Append(index int, detected bool) {
 		})
 	}
 
-	if len(detection.candidatesBuffer) == candidatesBufferSize {
+	if len(detection.candidatesBuffer) == detection.candidatesSize {
 		candidates := detection.getCandidateDetections()
 		detection.handleBufferCarriage(candidates)
+
+		// NOTE: The frames preceding the next candidates buffer can not be added as candidates, so the run can not be extended.
+		frontier := index - detection.candidatesSize + 2
+		if detection.runLast != -1 && detection.runLast+1 < frontier {
+			detection.closeRun()
+		}
 	}
 }
 
-func (detection *detectionBuffer) getCandidateDetections() []detectionBufferElement {
-	var (
-		a       = detection.candidatesBuffer[0]
-		b       = detection.candidatesBuffer[1]
-		c       = detection.candidatesBuffer[2]
-		d       = detection.candidatesBuffer[3]
-		results = make([]detectionBufferElement, 0, candidatesBufferSize)
-	)
-
-	if a.detected {
-		results = append(results, a)
+func (detection *detectionBuffer) Close() {
+	if len(detection.candidatesBuffer) < detection.candidatesSize {
+		candidates := detection.getCandidateDetections()
+		detection.handleBufferCarriage(candidates)
 	}
 
-	if b.detected || (a.detected && d.detected) || (a.detected && c.detected) {
-		results = append(results, b)
+	if detection.runLast != -1 {
+		detection.closeRun()
 	}
+}
 
-	if c.detected || (b.detected && d.detected) || (a.detected && d.detected) {
-		results = append(results, c)
-	}
+// Helper function used to select the detected candidates and the not detected candidates filling the gap between two detected
+// candidates which is not longer than the maximal gap.
+func (detection *detectionBuffer) getCandidateDetections() []detectionBufferElement {
+	results := make([]detectionBufferElement, 0, len(detection.candidatesBuffer))
 
-	if d.detected {
-		results = append(results, d)
+	previous := -1
+	for position, candidate := range detection.candidatesBuffer {
+		if candidate.detected {
+			if previous != -1 && position-previous-1 <= detection.maxGap {
+				results = append(results, detection.candidatesBuffer[previous+1:position]...)
+			}
+
+			results = append(results, candidate)
+			previous = position
+		}
 	}
 
 	return results
 }
 
 func (detection *detectionBuffer) handleBufferCarriage(candidateDetections []detectionBufferElement) {
-	offsetRange := utils.MinInt(len(detection.detectionsBuffer), candidatesBufferSize)
 	for _, candidate := range candidateDetections {
-		isPresent := false
-		for offset := 0; offset < offsetRange; offset += 1 {
-			if detection.detectionsBuffer[len(detection.detectionsBuffer)-1-offset].index == candidate.index {
-				isPresent = true
-			}
+		if candidate.index <= detection.lastCandidate {
+			continue
 		}
 
-		if !isPresent {
-			detection.detectionsBuffer = append(detection.detectionsBuffer, candidate)
+		detection.lastCandidate = candidate.index
+
+		if detection.runLast != -1 && candidate.index != detection.runLast+1 {
+			detection.closeRun()
 		}
+
+		if detection.runFirst == -1 {
+			detection.runFirst = candidate.index
+			detection.runResolved = candidate.index - 1
+		}
+
+		detection.runLast = candidate.index
+
+		// NOTE: The runs are resolved as soon as they reach the minimal length, unless the maximal length is limited.
+		if detection.maxRunLength == 0 && detection.runLast-detection.runFirst+1 >= detection.minRunLength {
+			detection.resolveRun()
+		}
+	}
+}
+
+func (detection *detectionBuffer) closeRun() {
+	length := detection.runLast - detection.runFirst + 1
+	if length >= detection.minRunLength && (detection.maxRunLength == 0 || length <= detection.maxRunLength) {
+		detection.resolveRun()
+	}
+
+	detection.runFirst, detection.runLast, detection.runResolved = -1, -1, -1
+}
+
+func (detection *detectionBuffer) resolveRun() {
+	for index := detection.runResolved + 1; index <= detection.runLast; index += 1 {
+		detection.detectionsBuffer = append(detection.detectionsBuffer, detectionBufferElement{
+			index:    index,
+			detected: true,
+		})
 	}
+
+	detection.runResolved = detection.runLast
 }
 
 func (detection *detectionBuffer) Resolve() []int {
@@ -102,6 +196,23 @@ func (detection *detectionBuffer) Resolve() []int {
 		results = append(results, detection.index)
 	}
 
-	// TODO: Do we need to sort it explicilty here?
 	return results
 }
+
+func (detection *detectionBuffer) ResolveLatest() []int {
+	results := make([]int, 0, len(detection.detectionsBuffer)-detection.resolvedCount)
+	for _, detection := range detection.detectionsBuffer[detection.resolvedCount:] {
+		results = append(results, detection.index)
+	}
+
+	detection.resolvedCount = len(detection.detectionsBuffer)
+	return results
+}
+
+func (detection *detectionBuffer) Latency() int {
+	if detection.maxRunLength != 0 {
+		return detection.candidatesSize + detection.maxRunLength
+	}
+
+	return detection.candidatesSize + detection.minRunLength - 1
+}
@@ -62,3 +62,90 @@ func TestDetectionBufferShouldNotStoreDuplicatesOnAppend(t *testing.T) {
 	assert.NotNil(t, actual)
 	assert.Equal(t, expected, actual)
 }
+
+func TestDetectionBufferShouldResolveLatestDetectionsIncrementally(t *testing.T) {
+	detections := []bool{false, true, false, true, false, false, false, false, true, true, false, false}
+	expected := []int{1, 2, 3, 8, 9}
+
+	detection := CreateDetectionBuffer()
+	actual := make([]int, 0)
+	for frameIndex, detected := range detections {
+		detection.Append(frameIndex, detected)
+
+		latest := detection.ResolveLatest()
+		for _, index := range latest {
+			assert.LessOrEqual(t, index, frameIndex)
+			assert.GreaterOrEqual(t, index, frameIndex-detection.Latency()+1)
+		}
+
+		actual = append(actual, latest...)
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, detection.Resolve(), actual)
+	assert.Empty(t, detection.ResolveLatest())
+}
+
+func TestDetectionBufferShouldResolveConfiguredGapsAndRuns(t *testing.T) {
+	cases := []struct {
+		candidatesSize int
+		maxGap         int
+		minRunLength   int
+		maxRunLength   int
+		detections     string
+		expected       []int
+	}{
+		{4, 2, 1, 0, "x..x....x", []int{0, 1, 2, 3, 8}},
+		{4, 1, 1, 0, "x..x....x", []int{0, 3, 8}},
+		{4, 0, 1, 0, "x.x.x", []int{0, 2, 4}},
+		{5, 3, 1, 0, "x...x", []int{0, 1, 2, 3, 4}},
+		{5, 2, 1, 0, "x...x", []int{0, 4}},
+		{6, 2, 1, 0, "x..x..x", []int{0, 1, 2, 3, 4, 5, 6}},
+		{2, 0, 1, 0, "xx.x", []int{0, 1, 3}},
+		{4, 2, 2, 0, "x....xx...x.x", []int{5, 6, 10, 11, 12}},
+		{4, 2, 3, 0, "x....xx...x.x", []int{10, 11, 12}},
+		{4, 2, 1, 2, "xxx....xx...x", []int{7, 8, 12}},
+		{4, 2, 2, 3, "x.x....xxxx....x", []int{0, 1, 2}},
+		{4, 2, 1, 0, "xx", []int{0, 1}},
+		{4, 2, 1, 0, "x.x", []int{0, 1, 2}},
+		{4, 2, 2, 0, "..xx", []int{2, 3}},
+		{4, 2, 1, 3, "....xxx", []int{4, 5, 6}},
+		{4, 2, 1, 3, "....xxxx", []int{}},
+	}
+
+	for _, c := range cases {
+		detection := CreateConfiguredDetectionBuffer(c.candidatesSize, c.maxGap, c.minRunLength, c.maxRunLength)
+
+		latest := make([]int, 0)
+		for frameIndex, symbol := range c.detections {
+			detection.Append(frameIndex, symbol == 'x')
+
+			resolved := detection.ResolveLatest()
+			for _, index := range resolved {
+				assert.GreaterOrEqual(t, index, frameIndex-detection.Latency()+1)
+			}
+
+			latest = append(latest, resolved...)
+		}
+
+		detection.Close()
+		latest = append(latest, detection.ResolveLatest()...)
+
+		assert.Equal(t, c.expected, detection.Resolve(), "detections: %s", c.detections)
+		assert.Equal(t, c.expected, latest, "detections: %s", c.detections)
+	}
+}
+
+func TestDetectionBufferShouldNotCreateWithInvalidParameters(t *testing.T) {
+	cases := [][4]int{
+		{1, 0, 1, 0},
+		{4, 3, 1, 0},
+		{4, -1, 1, 0},
+		{4, 2, 0, 0},
+		{4, 2, 3, 2},
+	}
+
+	for _, c := range cases {
+		assert.Panics(t, func() { CreateConfiguredDetectionBuffer(c[0], c[1], c[2], c[3]) })
+	}
+}
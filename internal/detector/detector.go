@@ -4,13 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
 	"path"
+	"path/filepath"
 	"strconv"
 	"time"
 
-	vidio "github.com/AlexEidt/Vidio"
 	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
 	"github.com/Krzysztofz01/video-lightning-detector/internal/render"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/source"
 	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/opts"
@@ -19,7 +21,36 @@ import (
 
 // Detector instance that is able to perform a search after ligntning strikes on a video file.
 type Detector interface {
-	Run(inputVideoPath, outputDirectoryPath string) error
+	Run(inputVideoPath, outputDirectoryPath string) (DetectionResult, error)
+}
+
+// Structure representing the results of a single detector run. The detections are represented by the frames ordinal numbers.
+// The cuts are the frames excluded from the detections by the scene cut detection. The analyzed frames are every stride-th frame
+// from the first to the last analyzed frame, which are zero if no frame was analyzed. The detection decisions of all frames are
+// stored by the frames indexes and are not stored by the streaming detection.
+type DetectionResult struct {
+	Video              VideoMetadata    `json:"video"`
+	Detections         []int            `json:"detections"`
+	Events             []LightningEvent `json:"events"`
+	Cuts               []SceneCut       `json:"cuts"`
+	AnalyzedFirstFrame int              `json:"analyzed-first-frame"`
+	AnalyzedLastFrame  int              `json:"analyzed-last-frame"`
+	FrameStride        int              `json:"frame-stride"`
+	Decisions          []FrameDecision  `json:"-"`
+}
+
+// Return the ascending sorted ordinal numbers of the analyzed frames.
+func (result DetectionResult) GetAnalyzedFrames() []int {
+	frames := make([]int, 0)
+	if result.AnalyzedFirstFrame == 0 || result.FrameStride < 1 {
+		return frames
+	}
+
+	for frameNumber := result.AnalyzedFirstFrame; frameNumber <= result.AnalyzedLastFrame; frameNumber += result.FrameStride {
+		frames = append(frames, frameNumber)
+	}
+
+	return frames
 }
 
 type detector struct {
@@ -46,59 +77,87 @@ func CreateDetector(renderer render.Renderer, options DetectorOptions) (Detector
 }
 
 // Perform a lightning detection on the provided video specified by the file path and store the results at the specified directory path.
-func (detector *detector) Run(inputVideoPath, outputDirectoryPath string) error {
+func (detector *detector) Run(inputVideoPath, outputDirectoryPath string) (DetectionResult, error) {
 	runTime := time.Now()
 	detector.renderer.LogInfo("Starting the lightning hunt.")
 
 	timings := make(map[string]time.Duration)
 
-	t0 := time.Now()
-	frames, err := detector.performVideoAnalysis(inputVideoPath)
-	if err != nil {
-		return fmt.Errorf("detector: video analysis stage failed: %w", err)
+	if err := detector.handleOptionsExport(outputDirectoryPath); err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: options export failed: %w", err)
 	}
-	timings["video_analysis"] = time.Since(t0)
 
-	if detector.options.AutoThresholds {
-		t1 := time.Now()
-		detector.applyAutoThresholds(frames)
-		timings["auto_thresholds"] = time.Since(t1)
+	var (
+		frames *frame.FramesCollection
+		video  VideoMetadata
+		result DetectionResult
+		err    error
+	)
+
+	if detector.options.Streaming {
+		ts := time.Now()
+		result, err = detector.performStreamingDetection(inputVideoPath, outputDirectoryPath)
+		if err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: streaming detection stage failed: %w", err)
+		}
+		timings["streaming_detection"] = time.Since(ts)
+	} else {
+		frames, video, result, err = detector.performBatchDetection(inputVideoPath, outputDirectoryPath, timings)
+		if err != nil {
+			return DetectionResult{}, err
+		}
+
+		if len(inputVideoPath) == 0 {
+			inputVideoPath = video.Path
+		}
 	}
 
-	detector.performStatisticsLogging(frames)
+	events := result.Events
 
-	t2 := time.Now()
-	detections := detector.performVideoDetection(frames)
-	timings["video_detection"] = time.Since(t2)
+	if detector.options.ExportClips && len(events) > 0 {
+		tclip := time.Now()
+		if err := detector.performClipsExport(inputVideoPath, outputDirectoryPath, events); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: failed to perform the lightning events clips export: %w", err)
+		}
+		timings["clips_export"] = time.Since(tclip)
+	}
 
-	if !detector.options.SkipFramesExport {
-		t3 := time.Now()
-		if err := detector.performFramesExport(inputVideoPath, outputDirectoryPath, detections); err != nil {
-			return fmt.Errorf("detector: failed to perform the detected frames images export: %w", err)
+	if (detector.options.ExportComposite || detector.options.ExportEventComposites) && len(events) > 0 {
+		tcomp := time.Now()
+		if err := detector.performCompositeExport(inputVideoPath, outputDirectoryPath, result.Detections, events); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: failed to perform the composite images export: %w", err)
 		}
-		timings["frames_export"] = time.Since(t3)
+		timings["composite_export"] = time.Since(tcomp)
 	}
 
 	if detector.options.ExportCsvReport {
 		t4 := time.Now()
-		if err := detector.handleCsvReportExport(outputDirectoryPath, frames); err != nil {
-			return fmt.Errorf("detector: csv report export failed: %w", err)
+		if err := detector.handleCsvReportExport(outputDirectoryPath, frames, events, result.Cuts); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: csv report export failed: %w", err)
 		}
 		timings["csv_report"] = time.Since(t4)
 	}
 
 	if detector.options.ExportJsonReport {
 		t5 := time.Now()
-		if err := detector.handleJsonReportExport(outputDirectoryPath, frames); err != nil {
-			return fmt.Errorf("detector: json report export failed: %w", err)
+		if err := detector.handleJsonReportExport(outputDirectoryPath, frames, events, result.Cuts); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: json report export failed: %w", err)
 		}
 		timings["json_report"] = time.Since(t5)
 	}
 
+	if detector.options.ExportExplainReport {
+		t7 := time.Now()
+		if err := detector.handleExplainReportExport(outputDirectoryPath, frames, result); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: explain report export failed: %w", err)
+		}
+		timings["explain_report"] = time.Since(t7)
+	}
+
 	if detector.options.ExportChartReport {
 		t6 := time.Now()
 		if err := detector.handleChartReportExport(outputDirectoryPath, frames); err != nil {
-			return fmt.Errorf("detector: chart report export failed: %w", err)
+			return DetectionResult{}, fmt.Errorf("detector: chart report export failed: %w", err)
 		}
 		timings["chart_report"] = time.Since(t6)
 	}
@@ -108,21 +167,97 @@ func (detector *detector) Run(inputVideoPath, outputDirectoryPath string) error
 
 	if detector.options.ExportTimingsReport {
 		if err := writeTimingsJSON(outputDirectoryPath, total, timings); err != nil {
-			return fmt.Errorf("detector: timings export failed: %w", err)
+			return DetectionResult{}, fmt.Errorf("detector: timings export failed: %w", err)
 		}
 	}
-	return nil
+
+	return result, nil
+}
+
+// Helper function used to analyze the whole video or import the analysis cache and perform the detection on the complete frames
+// collection. The strikes are optionally localized and the detected frames are exported after the detection. The stages durations are stored in the timings map.
+func (detector *detector) performBatchDetection(inputVideoPath, outputDirectoryPath string, timings map[string]time.Duration) (*frame.FramesCollection, VideoMetadata, DetectionResult, error) {
+	var (
+		frames *frame.FramesCollection
+		video  VideoMetadata
+		err    error
+	)
+
+	t0 := time.Now()
+	if len(detector.options.AnalysisCachePath) != 0 {
+		frames, video, err = detector.performAnalysisCacheImport(detector.options.AnalysisCachePath)
+		if err != nil {
+			return nil, VideoMetadata{}, DetectionResult{}, fmt.Errorf("detector: analysis cache import failed: %w", err)
+		}
+
+		if len(inputVideoPath) == 0 {
+			inputVideoPath = video.Path
+		}
+
+		timings["analysis_cache_import"] = time.Since(t0)
+	} else {
+		frames, video, err = detector.performVideoAnalysis(inputVideoPath)
+		if err != nil {
+			return nil, VideoMetadata{}, DetectionResult{}, fmt.Errorf("detector: video analysis stage failed: %w", err)
+		}
+
+		timings["video_analysis"] = time.Since(t0)
+	}
+
+	if detector.options.ExportAnalysisCache {
+		tc := time.Now()
+		if err := detector.handleAnalysisCacheExport(outputDirectoryPath, video, frames); err != nil {
+			return nil, VideoMetadata{}, DetectionResult{}, fmt.Errorf("detector: analysis cache export failed: %w", err)
+		}
+		timings["analysis_cache_export"] = time.Since(tc)
+	}
+
+	if detector.options.AutoThresholds {
+		t1 := time.Now()
+		detector.applyAutoThresholds(frames)
+		timings["auto_thresholds"] = time.Since(t1)
+	}
+
+	detector.performThresholdsLogging()
+	detector.performStatisticsLogging(frames)
+
+	t2 := time.Now()
+	detections, decisions, cuts := detector.performVideoDetection(frames, video.Frames)
+	timings["video_detection"] = time.Since(t2)
+
+	events := detector.performEventsGrouping(frames, detections)
+	result := detector.createDetectionResult(video, frames, detections, events)
+	result.Decisions = decisions
+	result.Cuts = cuts
+
+	if detector.options.LocalizeStrikes && len(events) > 0 {
+		tl := time.Now()
+		if err := detector.performStrikeLocalization(inputVideoPath, frames, result); err != nil {
+			return nil, VideoMetadata{}, DetectionResult{}, fmt.Errorf("detector: failed to perform the strike localization: %w", err)
+		}
+		timings["strike_localization"] = time.Since(tl)
+	}
+
+	if !detector.options.SkipFramesExport {
+		t3 := time.Now()
+		if err := detector.performFramesExport(inputVideoPath, outputDirectoryPath, frames, result.Detections, createFramesClock(video)); err != nil {
+			return nil, VideoMetadata{}, DetectionResult{}, fmt.Errorf("detector: failed to perform the detected frames images export: %w", err)
+		}
+		timings["frames_export"] = time.Since(t3)
+	}
+
+	return frames, video, result, nil
 }
 
 // Helper function used to iterate over the video frames in order to generate a collection of frames instances containing
 // processed values about given frames and neighbouring frames relations.
-func (detector *detector) performVideoAnalysis(inputVideoPath string) (*frame.FramesCollection, error) {
+func (detector *detector) performVideoAnalysis(inputVideoPath string) (*frame.FramesCollection, VideoMetadata, error) {
 	videoAnalysisTime := time.Now()
 	detector.renderer.LogDebug("Starting the video analysis stage.")
 
-	video, err := vidio.NewVideo(inputVideoPath)
+	video, err := detector.createFrameSource(inputVideoPath)
 	if err != nil {
-		return nil, fmt.Errorf("detector: failed to open the video file for the analysis stage: %w", err)
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to open the frame source for the analysis stage: %w", err)
 	}
 
 	defer video.Close()
@@ -130,221 +265,549 @@ func (detector *detector) performVideoAnalysis(inputVideoPath string) (*frame.Fr
 	targetWidth := int(float64(video.Width()) * detector.options.FrameScalingFactor)
 	targetHeight := int(float64(video.Height()) * detector.options.FrameScalingFactor)
 
-	frameCurrentBuffer := image.NewRGBA(image.Rect(0, 0, video.Width(), video.Height()))
-	video.SetFrameBuffer(frameCurrentBuffer.Pix)
-
+	frameCurrentBuffer := video.FrameBuffer()
 	frameCurrent := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
 	framePrevious := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
 
-	frameNumber := 1
+	frameMask, err := detector.createFrameMask(targetWidth, targetHeight)
+	if err != nil {
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to create the frame mask for the analysis stage: %w", err)
+	}
+
+	metadata, err := detector.createVideoMetadata(inputVideoPath, video)
+	if err != nil {
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to create the video metadata for the analysis stage: %w", err)
+	}
+
 	frameCount := video.Frames()
-	frames := frame.CreateNewFramesCollection(frameCount)
+	framesClock := createFramesClock(metadata)
+	motionCompensator := createMotionCompensator(detector.options, targetWidth, targetHeight)
+	sceneCutClassifier := createSceneCutClassifier(detector.options)
+
+	framesRange, err := createFramesRange(detector.options, video.FPS())
+	if err != nil {
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to create the frames range for the analysis stage: %w", err)
+	}
+
+	frames := frame.CreateNewFramesCollection(framesRange.Count(frameCount))
+	framesReader := createFramesRangeReader(framesRange, video)
+
+	progressBarStep, progressBarClose := detector.createProgress("Video analysis stage.", framesRange.Count(frameCount))
+
+	// NOTE: The analyzed frames are compared with the directly preceding frames of the video, which are not analyzed if the
+	// range does not start at the first frame or the frame stride is specified.
+	readPreviousFrame := func() error {
+		if err := utils.ScaleImage(frameCurrentBuffer, framePrevious, detector.options.FrameScalingFactor); err != nil {
+			return fmt.Errorf("detector: failed to scale the previous frame image on the analyze stage: %w", err)
+		}
+
+		if detector.options.Denoise {
+			if err := utils.BlurImage(framePrevious, framePrevious, 8); err != nil {
+				return fmt.Errorf("detector: failed to blur the previous frame image on the analyze stage: %w", err)
+			}
+		}
+
+		return nil
+	}
 
-	progressBarStep, progressBarClose := detector.renderer.Progress("Video analysis stage.", frameCount)
+	for {
+		ok, err := framesReader.Read(readPreviousFrame)
+		if err != nil {
+			return nil, VideoMetadata{}, err
+		}
+
+		if !ok {
+			break
+		}
 
-	for video.Read() {
-		if utils.ScaleImage(frameCurrentBuffer, frameCurrent, detector.options.FrameScalingFactor); err != nil {
-			return nil, fmt.Errorf("detector: failed to scale the current frame image on the analyze stage: %w", err)
+		frameNumber := framesReader.FrameNumber()
+
+		if err := utils.ScaleImage(frameCurrentBuffer, frameCurrent, detector.options.FrameScalingFactor); err != nil {
+			return nil, VideoMetadata{}, fmt.Errorf("detector: failed to scale the current frame image on the analyze stage: %w", err)
 		}
 
 		if detector.options.Denoise {
 			if err := utils.BlurImage(frameCurrent, frameCurrent, 8); err != nil {
-				return nil, fmt.Errorf("detector: failed to blur the current frame image on the analyze stage: %w", err)
+				return nil, VideoMetadata{}, fmt.Errorf("detector: failed to blur the current frame image on the analyze stage: %w", err)
 			}
 		}
 
-		frame := frame.CreateNewFrame(frameCurrent, framePrevious, frameNumber)
+		framePreviousCompensated, err := motionCompensator.Compensate(frameCurrent, framePrevious, frameNumber)
+		if err != nil {
+			return nil, VideoMetadata{}, fmt.Errorf("detector: failed to compensate the frame motion on the analyze stage: %w", err)
+		}
+
+		frame := frame.CreateNewTiledFrame(frameCurrent, framePreviousCompensated, frameNumber, frameMask, int(detector.options.TileColumns), int(detector.options.TileRows))
+		framesClock.Apply(frame)
+		motionCompensator.Apply(frame)
 		frames.Append(frame)
 
-		detector.renderer.LogDebug("Frame: [%d/%d]. Brightness: %f ColorDiff: %f BTDiff: %f", frameNumber, frameCount, frame.Brightness, frame.ColorDifference, frame.BinaryThresholdDifference)
+		if err := sceneCutClassifier.Classify(frameCurrent, framePrevious, frame); err != nil {
+			return nil, VideoMetadata{}, fmt.Errorf("detector: failed to classify the scene cut on the analyze stage: %w", err)
+		}
+
+		detector.renderer.LogDebug("%s Metrics: %v", getFrameLogPrefix(frameNumber, frameCount), frame.Metrics)
 
-		frameNumber += 1
 		progressBarStep()
 		copy(framePrevious.Pix, frameCurrent.Pix)
 	}
 
+	if err := video.Err(); err != nil {
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to read the frame on the analyze stage: %w", err)
+	}
+
 	progressBarClose()
 	detector.renderer.LogDebug("Video analysis stage finished. Stage took: %s", time.Since(videoAnalysisTime))
-	return frames, nil
+
+	return frames, metadata, nil
+}
+
+// Helper function used to create the metadata of the video provided by the frame source, including the timing metadata used to
+// assign the presentation timestamps and the wall-clock times to the frames. The options requiring the frame rate are rejected
+// before the analysis if the frame rate of the input is unknown, such as for the image sequences without the sequence frame rate.
+func (detector *detector) createVideoMetadata(inputVideoPath string, video source.FrameSource) (VideoMetadata, error) {
+	if err := detector.checkFrameRateRequirements(video.FPS()); err != nil {
+		return VideoMetadata{}, err
+	}
+
+	wallClockStart, err := resolveWallClockStart(detector.options, video)
+	if err != nil {
+		return VideoMetadata{}, fmt.Errorf("detector: failed to resolve the wall-clock start: %w", err)
+	}
+
+	startTimestamp := 0.0
+	if timedVideo, ok := video.(source.TimedFrameSource); ok {
+		startTimestamp = timedVideo.StartTime()
+	}
+
+	return VideoMetadata{
+		Path:           inputVideoPath,
+		Width:          video.Width(),
+		Height:         video.Height(),
+		Frames:         video.Frames(),
+		FPS:            video.FPS(),
+		Duration:       video.Duration(),
+		StartTimestamp: startTimestamp,
+		WallClockStart: wallClockStart,
+	}, nil
+}
+
+// Helper function used to check if the options requiring the frame rate of the input can be used with the given frame rate.
+func (detector *detector) checkFrameRateRequirements(fps float64) error {
+	if fps > 0 {
+		return nil
+	}
+
+	if len(detector.options.WallClockStart) != 0 {
+		return errors.New("detector: the wall-clock times of the frames require the frame rate of the input, which can be specified for the image sequences with the sequence frame rate")
+	}
+
+	if detector.options.ExportClips {
+		return errors.New("detector: the clips export requires the frame rate of the input, which can be specified for the image sequences with the sequence frame rate")
+	}
+
+	return nil
+}
+
+// Helper function used to create the frame source of the input specified by the path. The input is read as the raw RGBA frames
+// stream if the raw stream frame size is specified.
+func (detector *detector) createFrameSource(inputVideoPath string) (source.FrameSource, error) {
+	if detector.options.IsRawStream() {
+		return source.CreateRawStreamSource(
+			inputVideoPath,
+			int(detector.options.RawStreamWidth),
+			int(detector.options.RawStreamHeight),
+			detector.options.RawStreamFPS)
+	}
+
+	return source.CreateFrameSource(inputVideoPath, detector.options.SequenceFPS)
+}
+
+// Helper function used to create the progress bar of the stage with the given number of steps. The spinner is displayed instead
+// of the progress bar if the number of steps is unknown.
+func (detector *detector) createProgress(title string, steps int) (func(), func()) {
+	if steps == 0 {
+		return func() {}, detector.renderer.Spinner(title)
+	}
+
+	return detector.renderer.Progress(title, steps)
+}
+
+// Helper function used to create the frame mask of the given size based on the include and exclude regions and the mask image.
+// A nil mask is returned if no regions and no mask image are specified.
+func (detector *detector) createFrameMask(width, height int) (*frame.FrameMask, error) {
+	if len(detector.options.IncludeRegions) == 0 && len(detector.options.ExcludeRegions) == 0 && len(detector.options.MaskImagePath) == 0 {
+		return nil, nil
+	}
+
+	includeRegions := make([]frame.Region, 0, len(detector.options.IncludeRegions))
+	for _, value := range detector.options.IncludeRegions {
+		region, err := frame.ParseRegion(value)
+		if err != nil {
+			return nil, fmt.Errorf("detector: failed to parse the include region: %w", err)
+		}
+
+		includeRegions = append(includeRegions, region)
+	}
+
+	excludeRegions := make([]frame.Region, 0, len(detector.options.ExcludeRegions))
+	for _, value := range detector.options.ExcludeRegions {
+		region, err := frame.ParseRegion(value)
+		if err != nil {
+			return nil, fmt.Errorf("detector: failed to parse the exclude region: %w", err)
+		}
+
+		excludeRegions = append(excludeRegions, region)
+	}
+
+	var maskImage image.Image = nil
+	if len(detector.options.MaskImagePath) != 0 {
+		img, err := utils.ImportImage(detector.options.MaskImagePath)
+		if err != nil {
+			return nil, fmt.Errorf("detector: failed to import the mask image: %w", err)
+		}
+
+		maskImage = img
+	}
+
+	mask, err := frame.CreateNewFrameMask(width, height, detector.options.FrameScalingFactor, includeRegions, excludeRegions, maskImage)
+	if err != nil {
+		return nil, fmt.Errorf("detector: failed to create the frame mask: %w", err)
+	}
+
+	detector.renderer.LogInfo("Frame mask created. Included pixels: %d/%d", mask.Count(), width*height)
+	return mask, nil
+}
+
+// Helper function used to restore the frames collection and the video metadata from a previously exported analysis cache.
+func (detector *detector) performAnalysisCacheImport(analysisCachePath string) (*frame.FramesCollection, VideoMetadata, error) {
+	analysisCacheImportTime := time.Now()
+	detector.renderer.LogDebug("Starting the analysis cache import stage.")
+
+	cache, err := LoadAnalysisCache(analysisCachePath)
+	if err != nil {
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to load the analysis cache: %w", err)
+	}
+
+	if ok, msg := cache.IsMatching(detector.options); !ok {
+		detector.renderer.LogWarning("The analysis cache options are not matching the current options: %s. The cached values will be used.", msg)
+	}
+
+	frames, err := cache.GetFramesCollection()
+	if err != nil {
+		return nil, VideoMetadata{}, fmt.Errorf("detector: failed to access the analysis cache frames: %w", err)
+	}
+
+	detector.renderer.LogInfo("Loaded %d analyzed frames from the analysis cache: %s", len(cache.Frames), analysisCachePath)
+	detector.renderer.LogDebug("Analysis cache import stage finished. Stage took: %s", time.Since(analysisCacheImportTime))
+	return frames, cache.Video, nil
 }
 
 // Helper function used to auto-calculate the detection thresholds based on the frames and apply the threshold to the detector options
-// TODO: More versatile results could be achived using "moving standard deviation"???
-// TODO: Render thresholds in a table
 func (detector *detector) applyAutoThresholds(framesCollection *frame.FramesCollection) {
 	autoThresholdTime := time.Now()
 	detector.renderer.LogDebug("Starting the auto thresholds calculation stage.")
 
 	frames := framesCollection.GetAll()
-	statistics := framesCollection.CalculateStatistics(int(detector.options.MovingMeanResolution))
-
-	var (
-		gDiffBrightnessValue float64 = 0
-		gDiffBrightnessCount int     = 0
-		gDiffColorDiffValue  float64 = 0
-		gDiffColorDiffCount  int     = 0
-		gDiffBTDiffValue     float64 = 0
-		gDiffBTDiffCount     int     = 0
-	)
-
-	for i := 0; i < len(frames); i += 1 {
-		diffBrightness := frames[i].Brightness - statistics.BrightnessMovingMean[i]
-		if diffBrightness > 0 {
-			gDiffBrightnessValue += diffBrightness
-			gDiffBrightnessCount += 1
+	statistics := framesCollection.CalculateStatistics(int(detector.options.MovingMeanResolution), detector.options.StatisticsPercentiles...)
+	for _, name := range frame.GetMetricsNames() {
+		var threshold float64
+		switch detector.options.AutoThresholdsStrategy {
+		case MadAutoThresholdsStrategy:
+			threshold = detector.options.AutoThresholdsMadMultiplier * statistics.GetMetricStatistics(name).MedianAbsoluteDeviation
+		default:
+			threshold = calculateMeanDeviationThreshold(frames, statistics.GetMetricStatistics(name).MovingMean, name)
 		}
 
-		diffColorDiff := frames[i].ColorDifference - statistics.ColorDifferenceMovingMean[i]
-		if diffColorDiff > 0 {
-			gDiffColorDiffValue += diffColorDiff
-			gDiffColorDiffCount += 1
-		}
+		threshold = math.Min(threshold, 1.0)
 
-		diffBTDiff := frames[i].BinaryThresholdDifference - statistics.BinaryThresholdDifferenceMovingMean[i]
-		if diffBTDiff > 0 {
-			gDiffBTDiffValue += diffBTDiff
-			gDiffBTDiffCount += 1
+		if !detector.options.IsMetricThresholdExplicit(name) {
+			detector.options.SetMetricThreshold(name, threshold)
+		} else {
+			detector.renderer.LogWarning("The %s detection threshold (%f) value was explicitly specified and would not be replaced by the auto-calculated one (%f)",
+				name,
+				detector.options.GetMetricThreshold(name),
+				threshold)
 		}
 	}
 
-	gDiffBrightnessValue /= float64(gDiffBrightnessCount)
-	gDiffColorDiffValue /= float64(gDiffColorDiffCount)
-	gDiffBTDiffValue /= float64(gDiffBTDiffCount)
+	detector.renderer.LogDebug("Auto thresholds calculation stage finished. Stage took: %s", time.Since(autoThresholdTime))
+}
 
-	defaultOptions := GetDefaultDetectorOptions()
+// Helper function used to calculate the threshold as the mean of the positive differences between the metric values and the
+// moving mean. Zero is returned if none of the metric values exceeds the moving mean, such as for the constant metric values.
+func calculateMeanDeviationThreshold(frames []*frame.Frame, movingMean []float64, name string) float64 {
+	var (
+		gDiffValue float64 = 0
+		gDiffCount int     = 0
+	)
 
-	if defaultOptions.BrightnessDetectionThreshold == defaultOptions.BrightnessDetectionThreshold {
-		detector.options.BrightnessDetectionThreshold = gDiffBrightnessValue
-	} else {
-		detector.renderer.LogWarning("The brightness detection threshold (%f) value was explicitly specified and would not be replace by the auto-calculated one (%f)",
-			detector.options.BrightnessDetectionThreshold,
-			gDiffBrightnessValue)
+	for i := 0; i < len(frames); i += 1 {
+		diff := frames[i].GetMetricValue(name) - movingMean[i]
+		if diff > 0 {
+			gDiffValue += diff
+			gDiffCount += 1
+		}
 	}
 
-	if defaultOptions.ColorDifferenceDetectionThreshold == defaultOptions.ColorDifferenceDetectionThreshold {
-		detector.options.ColorDifferenceDetectionThreshold = gDiffColorDiffValue
-	} else {
-		detector.renderer.LogWarning("The color difference detection threshold (%f) value was explicitly specified and would not be replace by the auto-calculated one (%f)",
-			detector.options.ColorDifferenceDetectionThreshold,
-			gDiffColorDiffValue)
+	if gDiffCount == 0 {
+		return 0
 	}
 
-	if defaultOptions.BinaryThresholdDifferenceDetectionThreshold == defaultOptions.BinaryThresholdDifferenceDetectionThreshold {
-		detector.options.BinaryThresholdDifferenceDetectionThreshold = gDiffBTDiffValue
-	} else {
-		detector.renderer.LogWarning("The binary threshold detection threshold (%f) value was explicitly specified and would not be replace by the auto-calculated one (%f)",
-			detector.options.BinaryThresholdDifferenceDetectionThreshold,
-			gDiffBTDiffValue)
+	return gDiffValue / float64(gDiffCount)
+}
+
+// Helper function used to select the per-frame baseline values of the metric, which are compared with the frame values. The
+// moving median is used in the threshold detection mode when the thresholds are calculated automatically using the median
+// absolute deviation strategy, otherwise the moving mean is used.
+func getMetricBaseline(options DetectorOptions, statistics frame.FramesStatistics, name string) []float64 {
+	if options.DetectionMode == ThresholdDetectionMode && options.AutoThresholds && options.AutoThresholdsStrategy == MadAutoThresholdsStrategy {
+		return statistics.GetMetricStatistics(name).MovingMedian
 	}
 
-	detector.renderer.LogDebug("Auto thresholds calculation stage finished. Stage took: %s", time.Since(autoThresholdTime))
+	return statistics.GetMetricStatistics(name).MovingMean
 }
 
-// Helper function used to filter out indecies representing frames wihich meet the requirement thresholds.
-func (detector *detector) performVideoDetection(framesCollection *frame.FramesCollection) []int {
+// Helper function used to filter out indecies representing frames wihich meet the requirement thresholds. The frames classified
+// as the scene cuts are excluded from the detections and returned as the scene cuts. The frame count is the number of frames of
+// the video which is used only for logging.
+func (detector *detector) performVideoDetection(framesCollection *frame.FramesCollection, frameCount int) ([]int, []FrameDecision, []SceneCut) {
 	videoDetectionTime := time.Now()
 	detector.renderer.LogDebug("Starting the video detection stage.")
 
-	detections := CreateDetectionBuffer()
+	detections := createDetectionBuffer(detector.options)
+	hysteresis := &hysteresisState{}
 
 	frames := framesCollection.GetAll()
-	statistics := framesCollection.CalculateStatistics(int(detector.options.MovingMeanResolution))
+	decisions := make([]FrameDecision, 0, len(frames))
+	statistics := framesCollection.CalculateStatistics(int(detector.options.MovingMeanResolution), detector.options.StatisticsPercentiles...)
 
 	progressBarStep, progressBarClose := detector.renderer.Progress("Video detection stage.", len(frames))
 
 	for frameIndex, frame := range frames {
-		logPrefix := fmt.Sprintf("Frame: [%d/%d].", frameIndex+1, len(frames))
-		// In quiet-detections mode, suppress the low-value per-frame "Checking" debug line
+		logPrefix := getFrameLogPrefix(frame.OrdinalNumber, frameCount)
+		decision := detector.checkFrame(logPrefix, frame, getFrameMovingStatistics(detector.options, statistics, frameIndex), hysteresis.active)
+
+		detections.Append(frameIndex, detector.applyHysteresis(logPrefix, hysteresis, &decision))
+		decisions = append(decisions, decision)
+		progressBarStep()
+	}
+
+	detections.Close()
+
+	progressBarClose()
+	detector.renderer.LogDebug("Video detection stage finished. Stage took: %s", time.Since(videoDetectionTime))
+
+	resolved := detections.Resolve()
+	// Always emit a single-line machine-readable summary for total detections
+	detector.renderer.LogInfo("Detections: %d", len(resolved))
+
+	cuts := CreateSceneCuts(frames, decisions)
+	if detector.options.SceneCutDetection {
+		detector.renderer.LogInfo("Scene cuts: %d", len(cuts))
+	}
+
+	return resolved, decisions, cuts
+}
+
+// Helper function used to create the moving statistics accessor of the frame specified by the index using the precalculated
+// frames statistics.
+func getFrameMovingStatistics(options DetectorOptions, statistics frame.FramesStatistics, frameIndex int) movingStatistics {
+	return func(name string) (float64, float64) {
+		return getMetricBaseline(options, statistics, name)[frameIndex], statistics.GetMetricStatistics(name).MovingStandardDeviation[frameIndex]
+	}
+}
+
+// Helper function used to check if the frame meets the detection requirements based on the moving statistics provided by the
+// accessor and if it is the scene cut, given the detection of the preceding frame. The result of the check is logged with the
+// given prefix.
+func (detector *detector) checkFrame(logPrefix string, f *frame.Frame, statistics movingStatistics, precedingDetected bool) FrameDecision {
+	// In quiet-detections mode, suppress the low-value per-frame "Checking" debug line
+	if !detector.options.QuietDetections {
+		detector.renderer.LogDebug("%s Checking frame thresholds.", logPrefix)
+	}
+
+	decision := decideFrame(detector.options, f, statistics)
+	applySceneCut(detector.options, f, &decision, precedingDetected)
+	if decision.SceneCut {
 		if !detector.options.QuietDetections {
-			detector.renderer.LogDebug("%s Checking frame thresholds.", logPrefix)
+			detector.renderer.LogInfo("%s Frame classified as the scene cut. (%f >= %f)", logPrefix, f.SceneChange, detector.options.SceneCutThreshold)
 		}
 
-		if frame.Brightness < detector.options.BrightnessDetectionThreshold+statistics.BrightnessMovingMean[frameIndex] {
-			detector.renderer.LogDebug("%s Frame brightenss requirements not met. (%f < %f + %f)",
-				logPrefix,
-				frame.Brightness,
-				detector.options.BrightnessDetectionThreshold,
-				statistics.BrightnessMovingMean[frameIndex])
+		return decision
+	}
 
-			detections.Append(frameIndex, false)
-			progressBarStep()
-			continue
+	if !decision.Detected {
+		for _, name := range decision.GetFailedMetricsNames() {
+			metricDecision := decision.Metrics[name]
+			if detector.options.DetectionMode == ZScoreDetectionMode {
+				detector.renderer.LogDebug("%s Frame %s requirements not met. (%f - %f <= %f * %f)",
+					logPrefix,
+					name,
+					metricDecision.Value,
+					metricDecision.Baseline,
+					metricDecision.Threshold,
+					metricDecision.Deviation)
+			} else {
+				detector.renderer.LogDebug("%s Frame %s requirements not met. (%f < %f + %f)",
+					logPrefix,
+					name,
+					metricDecision.Value,
+					metricDecision.Threshold,
+					metricDecision.Baseline)
+			}
 		}
 
-		if frame.ColorDifference < detector.options.ColorDifferenceDetectionThreshold+statistics.ColorDifferenceMovingMean[frameIndex] {
-			detector.renderer.LogDebug("%s Frame color difference requirements not met. (%f < %f + %f)",
-				logPrefix,
-				frame.ColorDifference,
-				detector.options.ColorDifferenceDetectionThreshold,
-				statistics.ColorDifferenceMovingMean[frameIndex])
-
-			detections.Append(frameIndex, false)
-			progressBarStep()
-			continue
+		if detector.options.CombinationRule == WeightedCombinationRule {
+			detector.renderer.LogDebug("%s Frame score requirements not met. (%f < %f)", logPrefix, decision.Score, detector.options.CombinationScoreThreshold)
 		}
 
-		if frame.BinaryThresholdDifference < detector.options.BinaryThresholdDifferenceDetectionThreshold+statistics.BinaryThresholdDifferenceMovingMean[frameIndex] {
-			detector.renderer.LogDebug("%s Frame binary threshold difference requirements not met. (%f < %f + %f)",
-				logPrefix,
-				frame.BinaryThresholdDifference,
-				detector.options.BinaryThresholdDifferenceDetectionThreshold,
-				statistics.BinaryThresholdDifferenceMovingMean[frameIndex])
+		return decision
+	}
 
-			detections.Append(frameIndex, false)
-			progressBarStep()
-			continue
-		}
+	// Gate per-frame positive logs behind quiet option to reduce verbosity
+	if !detector.options.QuietDetections {
+		detector.renderer.LogInfo("%s Frame meets the threshold requirements.", logPrefix)
 
-		// Gate per-frame positive logs behind quiet option to reduce verbosity
-		if !detector.options.QuietDetections {
-			detector.renderer.LogInfo("%s Frame meets the threshold requirements.", logPrefix)
+		if decision.Tile != 0 {
+			detector.renderer.LogDebug("%s Frame tile %d meets the threshold requirements.", logPrefix, decision.Tile)
 		}
-		detections.Append(frameIndex, true)
+	}
 
-		progressBarStep()
+	return decision
+}
+
+// Helper function used to apply the hysteresis to the frame decision and log the frames detected thanks to the sustain
+// requirements with the given prefix. A boolean value representing if the frame is detected is returned.
+func (detector *detector) applyHysteresis(logPrefix string, state *hysteresisState, decision *FrameDecision) bool {
+	detected := state.Apply(decision)
+	if decision.Sustained && !detector.options.QuietDetections {
+		detector.renderer.LogInfo("%s Frame meets the sustain requirements following the detected frame.", logPrefix)
 	}
 
-	progressBarClose()
-	detector.renderer.LogDebug("Video detection stage finished. Stage took: %s", time.Since(videoDetectionTime))
+	return detected
+}
 
-	resolved := detections.Resolve()
-	// Always emit a single-line machine-readable summary for total detections
-	detector.renderer.LogInfo("Detections: %d", len(resolved))
-	return resolved
+// Perform the detection on the analyzed frames using the precalculated frames statistics, without logging and exporting. The
+// detections are returned as the frames ordinal numbers together with the detections grouped into lightning events.
+func DetectFrames(frames []*frame.Frame, statistics frame.FramesStatistics, options DetectorOptions) ([]int, []LightningEvent) {
+	detections := createDetectionBuffer(options)
+	hysteresis := &hysteresisState{}
+	for frameIndex, f := range frames {
+		decision := decideFrame(options, f, getFrameMovingStatistics(options, statistics, frameIndex))
+		applySceneCut(options, f, &decision, hysteresis.active)
+		detections.Append(frameIndex, hysteresis.Apply(&decision))
+	}
+
+	detections.Close()
+
+	indexes := detections.Resolve()
+
+	ordinalNumbers := make([]int, 0, len(indexes))
+	for _, frameIndex := range indexes {
+		ordinalNumbers = append(ordinalNumbers, frames[frameIndex].OrdinalNumber)
+	}
+
+	return ordinalNumbers, CreateLightningEvents(indexes, frames, int(options.EventFramesGap))
+}
+
+// Helper function used to group the detected frames indexes into lightning events.
+func (detector *detector) performEventsGrouping(framesCollection *frame.FramesCollection, detections []int) []LightningEvent {
+	events := CreateLightningEvents(detections, framesCollection.GetAll(), int(detector.options.EventFramesGap))
+
+	detector.renderer.LogInfo("Events: %d", len(events))
+	for index, event := range events {
+		detector.renderer.LogDebug("Event: [%d/%d]. Frames: %d-%d Peak: %d Peak time: %s", index+1, len(events), event.FirstFrame, event.LastFrame, event.PeakFrame, event.FormatPeakTime())
+	}
+
+	return events
+}
+
+// Helper function used to map the detected frames indexes to the frames ordinal numbers and create the run results.
+func (detector *detector) createDetectionResult(video VideoMetadata, framesCollection *frame.FramesCollection, detections []int, events []LightningEvent) DetectionResult {
+	frames := framesCollection.GetAll()
+
+	ordinalNumbers := make([]int, 0, len(detections))
+	for _, frameIndex := range detections {
+		ordinalNumbers = append(ordinalNumbers, frames[frameIndex].OrdinalNumber)
+	}
+
+	result := DetectionResult{
+		Video:       video,
+		Detections:  ordinalNumbers,
+		Events:      events,
+		FrameStride: int(detector.options.FrameStride),
+	}
+
+	if len(frames) != 0 {
+		result.AnalyzedFirstFrame = frames[0].OrdinalNumber
+		result.AnalyzedLastFrame = frames[len(frames)-1].OrdinalNumber
+	}
+
+	return result
 }
 
-// Helper function used to export frames which meet the requirement thresholds to png files.
-func (detector *detector) performFramesExport(inputVideoPath, outputDirectoryPath string, detections []int) error {
+// Helper function used to export frames which meet the requirement thresholds to png files. The detections are represented by
+// the frames ordinal numbers and the timestamps in the exported files names are provided by the frames clock. The changed regions
+// of the frames are optionally drawn onto the images, in which case the image files of the frames are not copied.
+func (detector *detector) performFramesExport(inputVideoPath, outputDirectoryPath string, framesCollection *frame.FramesCollection, detections []int, clock framesClock) error {
 	framesExportTime := time.Now()
 	detector.renderer.LogDebug("Starting the frames export stage.")
 	detector.renderer.LogInfo("About to export %d frames.", len(detections))
 
-	video, err := vidio.NewVideo(inputVideoPath)
+	video, err := detector.createFrameSource(inputVideoPath)
 	if err != nil {
-		return fmt.Errorf("detector: failed to open the video file for the frames export stage: %w", err)
+		return fmt.Errorf("detector: failed to open the frame source for the frames export stage: %w", err)
 	}
 
 	defer video.Close()
 
-	// TODO: Limit for large detections
-	frames, err := video.ReadFrames(detections...)
-	if err != nil {
-		return fmt.Errorf("detector: failed to read the specified frames from the video: %w", err)
-	}
-
 	progressBarStep, progressBarClose := detector.renderer.Progress("Video frames export stage.", len(detections))
 
-	for index, frame := range frames {
-		frameIndex := detections[index]
+	if fileVideo, ok := video.(source.FileFrameSource); ok && !detector.options.DrawRegions {
+		for _, frameNumber := range detections {
+			frameFilePath := fileVideo.GetFramePath(frameNumber - 1)
+			frameImageName := clock.GetFrameImageName(frameNumber, filepath.Ext(frameFilePath))
+			frameImagePath := path.Join(outputDirectoryPath, frameImageName)
+			if err := utils.CopyFile(frameFilePath, frameImagePath); err != nil {
+				return fmt.Errorf("detector: failed to copy the frame image file: %w", err)
+			}
+
+			progressBarStep()
+			detector.renderer.LogInfo("Frame: [%d/%d]. Frame image exported at: %s", frameNumber, video.Frames(), frameImagePath)
+		}
+	} else {
+		frameIndexes := make([]int, 0, len(detections))
+		for _, frameNumber := range detections {
+			frameIndexes = append(frameIndexes, frameNumber-1)
+		}
 
-		frameImageName := fmt.Sprintf("frame-%d.png", frameIndex+1)
-		frameImagePath := path.Join(outputDirectoryPath, frameImageName)
-		if err := utils.ExportImageAsPng(frameImagePath, frame); err != nil {
-			return fmt.Errorf("detector: failed to export the frame image: %w", err)
+		// TODO: Limit for large detections
+		frames, err := video.ReadFrames(frameIndexes...)
+		if err != nil {
+			return fmt.Errorf("detector: failed to read the specified frames from the video: %w", err)
 		}
 
-		progressBarStep()
-		detector.renderer.LogInfo("Frame: [%d/%d]. Frame image exported at: %s", frameIndex+1, video.Frames(), frameImagePath)
+		for index, frame := range frames {
+			frameNumber := detections[index]
+
+			if detector.options.DrawRegions {
+				if f, err := framesCollection.Get(frameNumber); err == nil {
+					if err := drawChangedRegions(frame, f.Regions); err != nil {
+						return err
+					}
+				}
+			}
+
+			frameImageName := clock.GetFrameImageName(frameNumber, ".png")
+			frameImagePath := path.Join(outputDirectoryPath, frameImageName)
+			if err := utils.ExportImageAsPng(frameImagePath, frame); err != nil {
+				return fmt.Errorf("detector: failed to export the frame image: %w", err)
+			}
+
+			progressBarStep()
+			detector.renderer.LogInfo("Frame: [%d/%d]. Frame image exported at: %s", frameNumber, video.Frames(), frameImagePath)
+		}
 	}
 
 	progressBarClose()
@@ -354,25 +817,92 @@ func (detector *detector) performFramesExport(inputVideoPath, outputDirectoryPat
 
 // Helper function used to print out descriptive statistics aboout the frames collection
 func (detector *detector) performStatisticsLogging(framesCollection *frame.FramesCollection) {
-	statistics := framesCollection.CalculateStatistics(int(detector.options.MovingMeanResolution))
+	statistics := framesCollection.CalculateStatistics(int(detector.options.MovingMeanResolution), detector.options.StatisticsPercentiles...)
+
+	values := make([][]string, 0)
+	for _, name := range frame.GetMetricsNames() {
+		metricStatistics := statistics.GetMetricStatistics(name)
+		values = append(values,
+			[]string{fmt.Sprintf("Frame %s mean", name), strconv.FormatFloat(metricStatistics.Mean, 'f', -1, 64)},
+			[]string{fmt.Sprintf("Frame %s standard deviation", name), strconv.FormatFloat(metricStatistics.StandardDeviation, 'f', -1, 64)},
+			[]string{fmt.Sprintf("Frame %s max", name), strconv.FormatFloat(metricStatistics.Max, 'f', -1, 64)},
+			[]string{fmt.Sprintf("Frame %s median", name), strconv.FormatFloat(metricStatistics.Median, 'f', -1, 64)},
+			[]string{fmt.Sprintf("Frame %s median absolute deviation", name), strconv.FormatFloat(metricStatistics.MedianAbsoluteDeviation, 'f', -1, 64)})
+
+		for _, percentileName := range metricStatistics.GetPercentilesNames() {
+			values = append(values, []string{fmt.Sprintf("Frame %s %s", name, percentileName), strconv.FormatFloat(metricStatistics.Percentiles[percentileName], 'f', -1, 64)})
+		}
+	}
+
+	detector.renderer.Table(values)
+}
 
-	values := [][]string{
-		{"Frame brightness mean", strconv.FormatFloat(statistics.BrightnessMean, 'f', -1, 64)},
-		{"Frame brightness standard deviation", strconv.FormatFloat(statistics.BrightnessStandardDeviation, 'f', -1, 64)},
-		{"Frame brightness max", strconv.FormatFloat(statistics.BrightnessMax, 'f', -1, 64)},
-		{"Frame color difference mean", strconv.FormatFloat(statistics.ColorDifferenceMean, 'f', -1, 64)},
-		{"Frame color difference standard deviation", strconv.FormatFloat(statistics.ColorDifferenceStandardDeviation, 'f', -1, 64)},
-		{"Frame color difference max", strconv.FormatFloat(statistics.ColorDifferenceMax, 'f', -1, 64)},
-		{"Frame color binary threshold mean", strconv.FormatFloat(statistics.BinaryThresholdDifferenceMean, 'f', -1, 64)},
-		{"Frame color binary threshold standard deviation", strconv.FormatFloat(statistics.BinaryThresholdDifferenceStandardDeviation, 'f', -1, 64)},
-		{"Frame color binary threshold max", strconv.FormatFloat(statistics.BinaryThresholdDifferenceMax, 'f', -1, 64)},
+// Helper function used to display the final detection thresholds together with their sources.
+func (detector *detector) performThresholdsLogging() {
+	values := [][]string{{"Metric", "Source", "Threshold"}}
+	for _, name := range frame.GetMetricsNames() {
+		values = append(values, []string{
+			name,
+			detector.options.GetMetricThresholdSource(name),
+			strconv.FormatFloat(detector.options.GetMetricThreshold(name), 'f', -1, 64),
+		})
 	}
 
 	detector.renderer.Table(values)
 }
 
-// Helper function used to export the frames collection report in the CSV format.
-func (detector *detector) handleCsvReportExport(outputDirectoryPath string, frames *frame.FramesCollection) error {
+// Helper function used to export the effective detector options, so the run can be reproduced using the options file as the config.
+func (detector *detector) handleOptionsExport(outputDirectoryPath string) error {
+	optionsPath := path.Join(outputDirectoryPath, OptionsFileName)
+	optionsFile, err := utils.CreateFileWithTree(optionsPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the options file: %w", err)
+	}
+
+	defer func() {
+		if err := optionsFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := detector.options.Export(optionsFile); err != nil {
+		return fmt.Errorf("detector: failed to export the options: %w", err)
+	}
+
+	detector.renderer.LogDebug("Effective options exported to: %s", optionsPath)
+	return nil
+}
+
+// Helper function used to export the frames collection together with the video metadata and options as the analysis cache.
+func (detector *detector) handleAnalysisCacheExport(outputDirectoryPath string, video VideoMetadata, frames *frame.FramesCollection) error {
+	cacheSpinnerStop := detector.renderer.Spinner("Exporting the analysis cache")
+	defer cacheSpinnerStop()
+
+	analysisCachePath := path.Join(outputDirectoryPath, AnalysisCacheFileName)
+	analysisCacheFile, err := utils.CreateFileWithTree(analysisCachePath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the analysis cache file: %w", err)
+	}
+
+	defer func() {
+		if err := analysisCacheFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	cache := CreateAnalysisCache(video, detector.options, frames)
+	if err := cache.Export(analysisCacheFile); err != nil {
+		return fmt.Errorf("detector: failed to export the analysis cache: %w", err)
+	} else {
+		detector.renderer.LogInfo("Analysis cache exported to: %s", analysisCachePath)
+	}
+
+	return nil
+}
+
+// Helper function used to export the frames collection report in the CSV format. The scene cuts report is exported only if the
+// scene cut detection is enabled.
+func (detector *detector) handleCsvReportExport(outputDirectoryPath string, frames *frame.FramesCollection, events []LightningEvent, cuts []SceneCut) error {
 	csvSpinnerStop := detector.renderer.Spinner("Exporting report in CSV format")
 	defer csvSpinnerStop()
 
@@ -406,18 +936,59 @@ func (detector *detector) handleCsvReportExport(outputDirectoryPath string, fram
 		}
 	}()
 
-	statistics := frames.CalculateStatistics(int(detector.options.MovingMeanResolution))
+	statistics := frames.CalculateStatistics(int(detector.options.MovingMeanResolution), detector.options.StatisticsPercentiles...)
 	if err := statistics.ExportCsvReport(statisticsReportFile); err != nil {
 		return fmt.Errorf("detector: failed to export the csv statistics report: %w", err)
 	} else {
 		detector.renderer.LogInfo("Statistics report in CSV format exported to: %s", csvStatisticsReportPath)
 	}
 
+	csvEventsReportPath := path.Join(outputDirectoryPath, "events-report.csv")
+	eventsReportFile, err := utils.CreateFileWithTree(csvEventsReportPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the csv events report file: %w", err)
+	}
+
+	defer func() {
+		if err := eventsReportFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := ExportLightningEventsCsvReport(eventsReportFile, events); err != nil {
+		return fmt.Errorf("detector: failed to export the csv events report: %w", err)
+	} else {
+		detector.renderer.LogInfo("Events report in CSV format exported to: %s", csvEventsReportPath)
+	}
+
+	if !detector.options.SceneCutDetection {
+		return nil
+	}
+
+	csvCutsReportPath := path.Join(outputDirectoryPath, "cuts-report.csv")
+	cutsReportFile, err := utils.CreateFileWithTree(csvCutsReportPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the csv scene cuts report file: %w", err)
+	}
+
+	defer func() {
+		if err := cutsReportFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := ExportSceneCutsCsvReport(cutsReportFile, cuts); err != nil {
+		return fmt.Errorf("detector: failed to export the csv scene cuts report: %w", err)
+	} else {
+		detector.renderer.LogInfo("Scene cuts report in CSV format exported to: %s", csvCutsReportPath)
+	}
+
 	return nil
 }
 
-// Helper function used to export the frames collection report in the JSON format.
-func (detector *detector) handleJsonReportExport(outputDirectoryPath string, frames *frame.FramesCollection) error {
+// Helper function used to export the frames collection report in the JSON format. The scene cuts report is exported only if the
+// scene cut detection is enabled.
+func (detector *detector) handleJsonReportExport(outputDirectoryPath string, frames *frame.FramesCollection, events []LightningEvent, cuts []SceneCut) error {
 	jsonSpinnerClose := detector.renderer.Spinner("Exporting the frames report in JSON format.")
 	defer jsonSpinnerClose()
 
@@ -451,13 +1022,99 @@ func (detector *detector) handleJsonReportExport(outputDirectoryPath string, fra
 		}
 	}()
 
-	statistics := frames.CalculateStatistics(int(detector.options.MovingMeanResolution))
+	statistics := frames.CalculateStatistics(int(detector.options.MovingMeanResolution), detector.options.StatisticsPercentiles...)
 	if err := statistics.ExportJsonReport(statisticsReportFile); err != nil {
 		return fmt.Errorf("detector: failed to export the json statistics report: %w", err)
 	} else {
 		detector.renderer.LogInfo("Statistics report in JSON format exported to %s", jsonStatisticsReportPath)
 	}
 
+	jsonEventsReportPath := path.Join(outputDirectoryPath, "events-report.json")
+	eventsReportFile, err := utils.CreateFileWithTree(jsonEventsReportPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the json events report file: %w", err)
+	}
+
+	defer func() {
+		if err := eventsReportFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := ExportLightningEventsJsonReport(eventsReportFile, events); err != nil {
+		return fmt.Errorf("detector: failed to export the json events report: %w", err)
+	} else {
+		detector.renderer.LogInfo("Events report in JSON format exported to %s", jsonEventsReportPath)
+	}
+
+	if !detector.options.SceneCutDetection {
+		return nil
+	}
+
+	jsonCutsReportPath := path.Join(outputDirectoryPath, "cuts-report.json")
+	cutsReportFile, err := utils.CreateFileWithTree(jsonCutsReportPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the json scene cuts report file: %w", err)
+	}
+
+	defer func() {
+		if err := cutsReportFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := ExportSceneCutsJsonReport(cutsReportFile, cuts); err != nil {
+		return fmt.Errorf("detector: failed to export the json scene cuts report: %w", err)
+	} else {
+		detector.renderer.LogInfo("Scene cuts report in JSON format exported to %s", jsonCutsReportPath)
+	}
+
+	return nil
+}
+
+// Helper function used to export the frames detection explanations report in the CSV and JSON format.
+func (detector *detector) handleExplainReportExport(outputDirectoryPath string, frames *frame.FramesCollection, result DetectionResult) error {
+	explainSpinnerClose := detector.renderer.Spinner("Exporting the detections explanations report.")
+	defer explainSpinnerClose()
+
+	explanations := CreateFramesExplanations(frames.GetAll(), result.Decisions, result.Detections)
+
+	csvExplainReportPath := path.Join(outputDirectoryPath, "detections-explain.csv")
+	csvExplainReportFile, err := utils.CreateFileWithTree(csvExplainReportPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the csv explanations report file: %w", err)
+	}
+
+	defer func() {
+		if err := csvExplainReportFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := ExportFramesExplanationsCsvReport(csvExplainReportFile, explanations); err != nil {
+		return fmt.Errorf("detector: failed to export the csv explanations report: %w", err)
+	} else {
+		detector.renderer.LogInfo("Explanations report in CSV format exported to: %s", csvExplainReportPath)
+	}
+
+	jsonExplainReportPath := path.Join(outputDirectoryPath, "detections-explain.json")
+	jsonExplainReportFile, err := utils.CreateFileWithTree(jsonExplainReportPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the json explanations report file: %w", err)
+	}
+
+	defer func() {
+		if err := jsonExplainReportFile.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if err := ExportFramesExplanationsJsonReport(jsonExplainReportFile, explanations); err != nil {
+		return fmt.Errorf("detector: failed to export the json explanations report: %w", err)
+	} else {
+		detector.renderer.LogInfo("Explanations report in JSON format exported to: %s", jsonExplainReportPath)
+	}
+
 	return nil
 }
 
@@ -486,34 +1143,27 @@ func (detector *detector) handleChartReportExport(outputDirectoryPath string, fr
 	chart.SetGlobalOptions(initializationOpts, titleOpts)
 
 	frames := framesCollection.GetAll()
+	names := frame.GetMetricsNames()
 
 	var (
-		xAxis           []int              = make([]int, 0, len(frames))
-		brightness      []opts.ScatterData = make([]opts.ScatterData, 0, len(frames))
-		colorDiff       []opts.ScatterData = make([]opts.ScatterData, 0, len(frames))
-		binaryThreshold []opts.ScatterData = make([]opts.ScatterData, 0, len(frames))
+		xAxis  []int                         = make([]int, 0, len(frames))
+		series map[string][]opts.ScatterData = make(map[string][]opts.ScatterData, len(names))
 	)
 
-	for frameIndex, frame := range frames {
-		xAxis = append(xAxis, frameIndex+1)
-
-		brightness = append(brightness, opts.ScatterData{
-			Value: frame.Brightness,
-		})
+	for _, frame := range frames {
+		xAxis = append(xAxis, frame.OrdinalNumber)
 
-		colorDiff = append(colorDiff, opts.ScatterData{
-			Value: frame.ColorDifference,
-		})
-
-		binaryThreshold = append(binaryThreshold, opts.ScatterData{
-			Value: frame.BinaryThresholdDifference,
-		})
+		for _, name := range names {
+			series[name] = append(series[name], opts.ScatterData{
+				Value: frame.GetMetricValue(name),
+			})
+		}
 	}
 
 	chart.SetXAxis(xAxis)
-	chart.AddSeries("Brightness", brightness)
-	chart.AddSeries("Color difference", colorDiff)
-	chart.AddSeries("Binary threshold", binaryThreshold)
+	for _, name := range names {
+		chart.AddSeries(name, series[name])
+	}
 
 	if err := chart.Render(chartReportFile); err != nil {
 		return fmt.Errorf("detector: failed to render the chart the the report file: %w", err)
@@ -0,0 +1,209 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFramesShouldReturnDetectionsAndEvents(t *testing.T) {
+	frames := mockFrames(40)
+	collection := frame.CreateNewFramesCollection(len(frames))
+	for _, f := range frames {
+		value := 0.1
+		if f.OrdinalNumber == 10 || f.OrdinalNumber == 11 || f.OrdinalNumber == 30 {
+			value = 0.9
+		}
+
+		for _, name := range frame.GetMetricsNames() {
+			f.Metrics[name] = value
+		}
+
+		assert.Nil(t, collection.Append(f))
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.MovingMeanResolution = 10
+	for _, name := range frame.GetMetricsNames() {
+		options.SetMetricThreshold(name, 0.2)
+	}
+
+	detections, events := DetectFrames(collection.GetAll(), collection.CalculateStatistics(10), options)
+
+	assert.Equal(t, []int{10, 11, 30}, detections)
+	assert.Len(t, events, 2)
+	assert.Equal(t, 10, events[0].FirstFrame)
+	assert.Equal(t, 11, events[0].LastFrame)
+	assert.Equal(t, 30, events[1].FirstFrame)
+}
+
+func TestAutoThresholdsShouldNotReplaceExplicitThresholds(t *testing.T) {
+	frames := mockFrames(40)
+	collection := frame.CreateNewFramesCollection(len(frames))
+	for _, f := range frames {
+		for _, name := range frame.GetMetricsNames() {
+			f.Metrics[name] = float64(f.OrdinalNumber%4) * 0.1
+		}
+
+		assert.Nil(t, collection.Append(f))
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.AutoThresholds = true
+	options.MovingMeanResolution = 10
+	options.SetMetricThreshold(frame.BrightnessMetricName, 0.5)
+	options.SetMetricThresholdExplicit(frame.BrightnessMetricName)
+
+	detectorInstance, err := CreateDetector(render.CreateRenderer(false), options)
+	assert.Nil(t, err)
+
+	instance := detectorInstance.(*detector)
+	instance.applyAutoThresholds(collection)
+
+	assert.Equal(t, 0.5, instance.options.GetMetricThreshold(frame.BrightnessMetricName))
+	assert.NotEqual(t, 0.0, instance.options.GetMetricThreshold(frame.ColorDifferenceMetricName))
+	assert.NotEqual(t, 0.0, instance.options.GetMetricThreshold(frame.BinaryThresholdDifferenceMetricName))
+}
+
+func TestAutoThresholdsShouldBeCalculatedForConstantFrames(t *testing.T) {
+	frames := mockFrames(5)
+	collection := frame.CreateNewFramesCollection(len(frames))
+	for _, f := range frames {
+		for _, name := range frame.GetMetricsNames() {
+			f.Metrics[name] = 0.2
+		}
+
+		assert.Nil(t, collection.Append(f))
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.AutoThresholds = true
+	options.MovingMeanResolution = 10
+
+	detectorInstance, err := CreateDetector(render.CreateRenderer(false), options)
+	assert.Nil(t, err)
+
+	instance := detectorInstance.(*detector)
+	instance.applyAutoThresholds(collection)
+
+	for _, name := range frame.GetMetricsNames() {
+		assert.Equal(t, 0.0, instance.options.GetMetricThreshold(name))
+	}
+
+	valid, msg := instance.options.AreValid()
+	assert.True(t, valid, msg)
+}
+
+func TestAutoThresholdsShouldUseMedianAbsoluteDeviationStrategy(t *testing.T) {
+	frames := mockFrames(40)
+	collection := frame.CreateNewFramesCollection(len(frames))
+	for _, f := range frames {
+		value := 0.1 + float64(f.OrdinalNumber%2)*0.02
+		if f.OrdinalNumber == 20 {
+			value = 0.9
+		}
+
+		for _, name := range frame.GetMetricsNames() {
+			f.Metrics[name] = value
+		}
+
+		assert.Nil(t, collection.Append(f))
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.AutoThresholds = true
+	options.AutoThresholdsStrategy = MadAutoThresholdsStrategy
+	options.AutoThresholdsMadMultiplier = 4
+	options.MovingMeanResolution = 10
+
+	detectorInstance, err := CreateDetector(render.CreateRenderer(false), options)
+	assert.Nil(t, err)
+
+	instance := detectorInstance.(*detector)
+	instance.applyAutoThresholds(collection)
+
+	for _, name := range frame.GetMetricsNames() {
+		assert.InDelta(t, 0.04, instance.options.GetMetricThreshold(name), 1e-7)
+	}
+
+	detections, _ := DetectFrames(collection.GetAll(), collection.CalculateStatistics(10), instance.options)
+	assert.Equal(t, []int{20}, detections)
+}
+
+func TestDetectFramesShouldDetectInZScoreMode(t *testing.T) {
+	frames := mockFrames(80)
+	collection := frame.CreateNewFramesCollection(len(frames))
+	for _, f := range frames {
+		// NOTE: The first half of the frames is calm and the second half is noisy, so the same flash is only significant in
+		// the calm section.
+		value := 0.2 + float64(f.OrdinalNumber%2)*0.01
+		if f.OrdinalNumber > 40 {
+			value = 0.2 + float64(f.OrdinalNumber%2)*0.3
+		}
+
+		if f.OrdinalNumber == 20 || f.OrdinalNumber == 60 {
+			value += 0.2
+		}
+
+		for _, name := range frame.GetMetricsNames() {
+			f.Metrics[name] = value
+		}
+
+		assert.Nil(t, collection.Append(f))
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.DetectionMode = ZScoreDetectionMode
+	options.ZScoreSigma = 3
+	options.MovingMeanResolution = 10
+
+	detections, events := DetectFrames(collection.GetAll(), collection.CalculateStatistics(10), options)
+	assert.Equal(t, []int{20}, detections)
+	assert.Len(t, events, 1)
+}
+
+func TestDetectionResultShouldReturnAnalyzedFrames(t *testing.T) {
+	result := DetectionResult{AnalyzedFirstFrame: 5, AnalyzedLastFrame: 17, FrameStride: 4}
+	assert.Equal(t, []int{5, 9, 13, 17}, result.GetAnalyzedFrames())
+
+	result = DetectionResult{}
+	assert.Empty(t, result.GetAnalyzedFrames())
+}
+
+func TestDetectorShouldRequireFrameRateOfImageSequence(t *testing.T) {
+	modifiers := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.ExportClips = true },
+		func(options *DetectorOptions) { options.WallClockStart = "2024-06-01 21:30:05" },
+	}
+
+	for _, modifier := range modifiers {
+		options := GetDefaultDetectorOptions()
+		options.AutoThresholds = true
+		options.SkipFramesExport = true
+		modifier(&options)
+
+		detectorInstance, err := CreateDetector(render.CreateRenderer(false), options)
+		assert.Nil(t, err)
+
+		_, err = detectorInstance.Run("../../resources/samples/sample_yes_sequence", t.TempDir())
+		assert.NotNil(t, err)
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.AutoThresholds = true
+	options.SkipFramesExport = true
+	options.SequenceFPS = 25
+	options.WallClockStart = "2024-06-01T21:30:05Z"
+
+	detectorInstance, err := CreateDetector(render.CreateRenderer(false), options)
+	assert.Nil(t, err)
+
+	result, err := detectorInstance.Run("../../resources/samples/sample_yes_sequence", t.TempDir())
+	assert.Nil(t, err)
+	assert.Equal(t, 25.0, result.Video.FPS)
+	assert.InDelta(t, 1.2, result.Video.Duration, 1e-9)
+	assert.NotEmpty(t, result.Events)
+	assert.InDelta(t, float64(result.Events[0].FirstFrame-1)/25, result.Events[0].FirstTimestamp, 1e-9)
+}
@@ -0,0 +1,211 @@
+package detector
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
+// Structure representing a single lightning event which is a group of consecutive or near-consecutive detected frames.
+// The frame values are represented by the frames ordinal numbers together with the frames presentation timestamps in seconds
+// and the frames wall-clock times, which are nil if unknown. The peak and mean values are stored by the metrics names. The regions
+// are the changed regions of the peak frame found by the strike localization.
+type LightningEvent struct {
+	FirstFrame     int                   `json:"first-frame"`
+	LastFrame      int                   `json:"last-frame"`
+	PeakFrame      int                   `json:"peak-frame"`
+	DurationFrames int                   `json:"duration-frames"`
+	FirstTimestamp float64               `json:"first-timestamp"`
+	LastTimestamp  float64               `json:"last-timestamp"`
+	PeakTimestamp  float64               `json:"peak-timestamp"`
+	FirstTime      *time.Time            `json:"first-time,omitempty"`
+	LastTime       *time.Time            `json:"last-time,omitempty"`
+	PeakTime       *time.Time            `json:"peak-time,omitempty"`
+	PeakValues     map[string]float64    `json:"peak-values"`
+	MeanValues     map[string]float64    `json:"mean-values"`
+	ClipPath       string                `json:"clip-path,omitempty"`
+	ClipFirstFrame int                   `json:"clip-first-frame,omitempty"`
+	ClipLastFrame  int                   `json:"clip-last-frame,omitempty"`
+	Regions        []frame.ChangedRegion `json:"regions,omitempty"`
+}
+
+// Group the detections represented by ascending sorted frames indexes into lightning events. Detections separated by at most
+// maxGap not detected frames are merged into a single event. The peak frame is the frame with the highest brightness and the
+// mean values are calculated over all frames in the range of the event.
+func CreateLightningEvents(detections []int, frames []*frame.Frame, maxGap int) []LightningEvent {
+	events := make([]LightningEvent, 0)
+	if len(detections) == 0 {
+		return events
+	}
+
+	first, last := detections[0], detections[0]
+	for _, index := range detections[1:] {
+		if index-last-1 > maxGap {
+			events = append(events, createLightningEvent(frames, first, last))
+			first = index
+		}
+
+		last = index
+	}
+
+	return append(events, createLightningEvent(frames, first, last))
+}
+
+func createLightningEvent(frames []*frame.Frame, firstIndex, lastIndex int) LightningEvent {
+	names := frame.GetMetricsNames()
+	event := LightningEvent{
+		FirstFrame:     frames[firstIndex].OrdinalNumber,
+		LastFrame:      frames[lastIndex].OrdinalNumber,
+		PeakFrame:      frames[firstIndex].OrdinalNumber,
+		DurationFrames: frames[lastIndex].OrdinalNumber - frames[firstIndex].OrdinalNumber + 1,
+		FirstTimestamp: frames[firstIndex].Timestamp,
+		LastTimestamp:  frames[lastIndex].Timestamp,
+		PeakTimestamp:  frames[firstIndex].Timestamp,
+		FirstTime:      frames[firstIndex].Time,
+		LastTime:       frames[lastIndex].Time,
+		PeakTime:       frames[firstIndex].Time,
+		PeakValues:     make(map[string]float64, len(names)),
+		MeanValues:     make(map[string]float64, len(names)),
+	}
+
+	for index := firstIndex; index <= lastIndex; index += 1 {
+		f := frames[index]
+
+		for _, name := range names {
+			value := f.GetMetricValue(name)
+
+			if value > event.PeakValues[name] {
+				event.PeakValues[name] = value
+
+				if name == frame.BrightnessMetricName {
+					event.PeakFrame = f.OrdinalNumber
+					event.PeakTimestamp = f.Timestamp
+					event.PeakTime = f.Time
+				}
+			}
+
+			event.MeanValues[name] += value
+		}
+	}
+
+	for _, name := range names {
+		event.MeanValues[name] /= float64(lastIndex - firstIndex + 1)
+	}
+
+	return event
+}
+
+// Convert the lightning event to the string buffer format accepted by the CSV encoder. The peak and mean values are ordered as
+// the frame metrics.
+func (event *LightningEvent) ToBuffer() []string {
+	buffer := []string{
+		strconv.Itoa(event.FirstFrame),
+		strconv.Itoa(event.LastFrame),
+		strconv.Itoa(event.PeakFrame),
+		strconv.Itoa(event.DurationFrames),
+		strconv.FormatFloat(event.FirstTimestamp, 'f', -1, 64),
+		strconv.FormatFloat(event.LastTimestamp, 'f', -1, 64),
+		strconv.FormatFloat(event.PeakTimestamp, 'f', -1, 64),
+		formatEventTime(event.FirstTime),
+		formatEventTime(event.LastTime),
+		formatEventTime(event.PeakTime),
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		buffer = append(buffer,
+			strconv.FormatFloat(event.PeakValues[name], 'f', -1, 64),
+			strconv.FormatFloat(event.MeanValues[name], 'f', -1, 64))
+	}
+
+	buffer = append(buffer,
+		event.ClipPath,
+		strconv.Itoa(event.ClipFirstFrame),
+		strconv.Itoa(event.ClipLastFrame))
+
+	if len(event.Regions) == 0 {
+		return append(buffer, "", "", "", "", "", "", "")
+	}
+
+	region := event.Regions[0]
+	return append(buffer,
+		strconv.Itoa(region.X),
+		strconv.Itoa(region.Y),
+		strconv.Itoa(region.Width),
+		strconv.Itoa(region.Height),
+		strconv.FormatFloat(region.CentroidX, 'f', -1, 64),
+		strconv.FormatFloat(region.CentroidY, 'f', -1, 64),
+		strconv.Itoa(region.Area))
+}
+
+// Return the wall-clock time of the event peak frame in the RFC 3339 format or the presentation timestamp of the peak frame in
+// seconds if the wall-clock time is unknown.
+func (event *LightningEvent) FormatPeakTime() string {
+	if event.PeakTime != nil {
+		return formatEventTime(event.PeakTime)
+	}
+
+	return strconv.FormatFloat(event.PeakTimestamp, 'f', 3, 64) + "s"
+}
+
+func formatEventTime(eventTime *time.Time) string {
+	if eventTime == nil {
+		return ""
+	}
+
+	return eventTime.Format(time.RFC3339Nano)
+}
+
+// Write the CSV format lightning events report to the provided writer which can be a file reference.
+func ExportLightningEventsCsvReport(file io.Writer, events []LightningEvent) error {
+	csvWriter := csv.NewWriter(file)
+	header := []string{
+		"Event",
+		"FirstFrame",
+		"LastFrame",
+		"PeakFrame",
+		"DurationFrames",
+		"FirstTimestamp",
+		"LastTimestamp",
+		"PeakTimestamp",
+		"FirstTime",
+		"LastTime",
+		"PeakTime",
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		header = append(header, "peak-"+name, "mean-"+name)
+	}
+
+	header = append(header, "ClipPath", "ClipFirstFrame", "ClipLastFrame")
+	header = append(header, "PeakRegionX", "PeakRegionY", "PeakRegionWidth", "PeakRegionHeight", "PeakRegionCentroidX", "PeakRegionCentroidY", "PeakRegionArea")
+
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("detector: failed to write the header to the events report file: %w", err)
+	}
+
+	for index, event := range events {
+		if err := csvWriter.Write(append([]string{strconv.Itoa(index + 1)}, event.ToBuffer()...)); err != nil {
+			return fmt.Errorf("detector: failed to write the event to the events report file: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	return nil
+}
+
+// Write the JSON format lightning events report to the provided writer which can be a file reference.
+func ExportLightningEventsJsonReport(file io.Writer, events []LightningEvent) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(events); err != nil {
+		return fmt.Errorf("detector: failed to encode the lightning events to json report file: %w", err)
+	}
+
+	return nil
+}
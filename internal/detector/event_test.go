@@ -0,0 +1,136 @@
+package detector
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLightningEventsShouldNotBeCreatedForNoDetections(t *testing.T) {
+	events := CreateLightningEvents([]int{}, mockFrames(5), 2)
+
+	assert.NotNil(t, events)
+	assert.Empty(t, events)
+}
+
+func TestLightningEventsShouldGroupDetections(t *testing.T) {
+	cases := []struct {
+		detections []int
+		maxGap     int
+		expected   [][2]int
+	}{
+		{[]int{3}, 2, [][2]int{{4, 4}}},
+		{[]int{1, 2, 3}, 0, [][2]int{{2, 4}}},
+		{[]int{1, 3}, 0, [][2]int{{2, 2}, {4, 4}}},
+		{[]int{1, 3}, 1, [][2]int{{2, 4}}},
+		{[]int{0, 1, 4, 5, 9}, 2, [][2]int{{1, 6}, {10, 10}}},
+		{[]int{0, 1, 4, 5, 9}, 1, [][2]int{{1, 2}, {5, 6}, {10, 10}}},
+	}
+
+	for _, c := range cases {
+		events := CreateLightningEvents(c.detections, mockFrames(10), c.maxGap)
+
+		assert.Len(t, events, len(c.expected))
+		for index, event := range events {
+			assert.Equal(t, c.expected[index][0], event.FirstFrame)
+			assert.Equal(t, c.expected[index][1], event.LastFrame)
+			assert.Equal(t, c.expected[index][1]-c.expected[index][0]+1, event.DurationFrames)
+		}
+	}
+}
+
+func TestLightningEventShouldStoreFramesTimes(t *testing.T) {
+	wallClockStart := time.Date(2024, 6, 1, 21, 30, 5, 0, time.UTC)
+	clock := createFramesClock(VideoMetadata{FPS: 10, WallClockStart: &wallClockStart})
+
+	frames := mockFrames(6)
+	for _, f := range frames {
+		clock.Apply(f)
+	}
+
+	frames[3].Metrics[frame.BrightnessMetricName] = 0.6
+
+	events := CreateLightningEvents([]int{2, 3, 4}, frames, 0)
+	assert.Len(t, events, 1)
+
+	event := events[0]
+	assert.InDelta(t, 0.2, event.FirstTimestamp, 1e-9)
+	assert.InDelta(t, 0.4, event.LastTimestamp, 1e-9)
+	assert.InDelta(t, 0.3, event.PeakTimestamp, 1e-9)
+	assert.Equal(t, wallClockStart.Add(200*time.Millisecond), *event.FirstTime)
+	assert.Equal(t, wallClockStart.Add(400*time.Millisecond), *event.LastTime)
+	assert.Equal(t, wallClockStart.Add(300*time.Millisecond), *event.PeakTime)
+	assert.Equal(t, "2024-06-01T21:30:05.3Z", event.FormatPeakTime())
+
+	event.PeakTime = nil
+	assert.Equal(t, "0.300s", event.FormatPeakTime())
+}
+
+func TestLightningEventShouldCalculatePeakAndMeanValues(t *testing.T) {
+	frames := mockFrames(4)
+	frames[1].Metrics[frame.BrightnessMetricName] = 0.2
+	frames[2].Metrics[frame.BrightnessMetricName] = 0.6
+	frames[2].Metrics[frame.ColorDifferenceMetricName] = 0.4
+	frames[3].Metrics[frame.BrightnessMetricName] = 0.4
+	frames[3].Metrics[frame.BinaryThresholdDifferenceMetricName] = 0.8
+
+	events := CreateLightningEvents([]int{1, 2, 3}, frames, 0)
+	assert.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, 3, event.PeakFrame)
+	assert.InDelta(t, 0.6, event.PeakValues[frame.BrightnessMetricName], 1e-9)
+	assert.InDelta(t, 0.4, event.MeanValues[frame.BrightnessMetricName], 1e-9)
+	assert.InDelta(t, 0.4, event.PeakValues[frame.ColorDifferenceMetricName], 1e-9)
+	assert.InDelta(t, 0.4/3.0, event.MeanValues[frame.ColorDifferenceMetricName], 1e-9)
+	assert.InDelta(t, 0.8, event.PeakValues[frame.BinaryThresholdDifferenceMetricName], 1e-9)
+	assert.InDelta(t, 0.8/3.0, event.MeanValues[frame.BinaryThresholdDifferenceMetricName], 1e-9)
+}
+
+func TestLightningEventsShouldExportCsvReport(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	events := CreateLightningEvents([]int{1, 2}, mockFrames(4), 0)
+
+	err := ExportLightningEventsCsvReport(buffer, events)
+	assert.Nil(t, err)
+
+	assert.NotZero(t, buffer.Len())
+}
+
+func TestLightningEventsShouldExportJsonReport(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	events := CreateLightningEvents([]int{1, 2}, mockFrames(4), 0)
+
+	err := ExportLightningEventsJsonReport(buffer, events)
+	assert.Nil(t, err)
+
+	assert.NotZero(t, buffer.Len())
+}
+
+func mockFrames(count int) []*frame.Frame {
+	frames := make([]*frame.Frame, 0, count)
+	for index := 0; index < count; index += 1 {
+		frames = append(frames, &frame.Frame{
+			OrdinalNumber: index + 1,
+			Metrics:       make(map[string]float64),
+		})
+	}
+
+	return frames
+}
+
+func TestLightningEventShouldConvertPeakRegionToBuffer(t *testing.T) {
+	events := CreateLightningEvents([]int{1, 2}, mockFrames(4), 0)
+	assert.Equal(t, []string{"", "", "", "", "", "", ""}, events[0].ToBuffer()[len(events[0].ToBuffer())-7:])
+
+	events[0].Regions = []frame.ChangedRegion{
+		{X: 10, Y: 20, Width: 30, Height: 40, CentroidX: 25.5, CentroidY: 40.25, Area: 600},
+		{X: 1, Y: 2, Width: 3, Height: 4, CentroidX: 2, CentroidY: 3, Area: 10},
+	}
+
+	buffer := events[0].ToBuffer()
+	assert.Equal(t, []string{"10", "20", "30", "40", "25.5", "40.25", "600"}, buffer[len(buffer)-7:])
+}
@@ -0,0 +1,154 @@
+package detector
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
+const (
+	PassedFrameStatus    string = "passed"
+	FailedFrameStatus    string = "failed"
+	SustainedFrameStatus string = "sustained"
+	AddedFrameStatus     string = "added"
+	RemovedFrameStatus   string = "removed"
+	SceneCutFrameStatus  string = "cut"
+)
+
+// Structure representing the explanation of the detection result of a single frame. The status is "passed" or "failed" if the
+// final detection is the same as the frame decision, "sustained" if the not detected frame was detected by the hysteresis,
+// "added" if the not detected frame was added by the detection buffer gap filling and "removed" if the detected frame was
+// removed by the detection buffer. The status is "cut" if the frame met the requirements, but was classified as the scene cut and
+// not detected. The baseline of the metrics is the moving mean or the moving median used for the comparison.
+// The timestamp is the presentation timestamp of the frame and the time is the wall-clock time of the frame, which is nil if unknown.
+// The tile is the number of the frame tile which met the requirements, which is zero if none of the tiles met them.
+type FrameExplanation struct {
+	Frame         int                       `json:"frame"`
+	Timestamp     float64                   `json:"timestamp"`
+	Time          *time.Time                `json:"time,omitempty"`
+	Status        string                    `json:"status"`
+	Detected      bool                      `json:"detected"`
+	PassedMetrics int                       `json:"passed-metrics"`
+	Score         float64                   `json:"score"`
+	Tile          int                       `json:"tile,omitempty"`
+	Metrics       map[string]MetricDecision `json:"metrics"`
+}
+
+// Create the explanations of all frames based on the frames decisions stored by the frames indexes and the final detections
+// represented by the frames ordinal numbers.
+func CreateFramesExplanations(frames []*frame.Frame, decisions []FrameDecision, detections []int) []FrameExplanation {
+	detected := make(map[int]bool, len(detections))
+	for _, ordinalNumber := range detections {
+		detected[ordinalNumber] = true
+	}
+
+	explanations := make([]FrameExplanation, 0, len(decisions))
+	for frameIndex, decision := range decisions {
+		ordinalNumber := frames[frameIndex].OrdinalNumber
+		explanation := FrameExplanation{
+			Frame:         ordinalNumber,
+			Timestamp:     frames[frameIndex].Timestamp,
+			Time:          frames[frameIndex].Time,
+			Detected:      detected[ordinalNumber],
+			PassedMetrics: decision.Passed,
+			Score:         decision.Score,
+			Tile:          decision.Tile,
+			Metrics:       decision.Metrics,
+		}
+
+		switch {
+		case decision.Detected && explanation.Detected:
+			explanation.Status = PassedFrameStatus
+		case decision.Detected || (decision.Sustained && !explanation.Detected):
+			explanation.Status = RemovedFrameStatus
+		case decision.SceneCut && !explanation.Detected:
+			explanation.Status = SceneCutFrameStatus
+		case decision.Sustained:
+			explanation.Status = SustainedFrameStatus
+		case explanation.Detected:
+			explanation.Status = AddedFrameStatus
+		default:
+			explanation.Status = FailedFrameStatus
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations
+}
+
+// Convert the frame explanation to the string buffer format accepted by the CSV encoder. The metrics checks are ordered as the
+// frame metrics.
+func (explanation *FrameExplanation) ToBuffer() []string {
+	buffer := []string{
+		strconv.Itoa(explanation.Frame),
+		strconv.FormatFloat(explanation.Timestamp, 'f', -1, 64),
+		formatEventTime(explanation.Time),
+		explanation.Status,
+		strconv.FormatBool(explanation.Detected),
+		strconv.Itoa(explanation.PassedMetrics),
+		strconv.FormatFloat(explanation.Score, 'f', -1, 64),
+		strconv.Itoa(explanation.Tile),
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		metricDecision := explanation.Metrics[name]
+		buffer = append(buffer,
+			strconv.FormatFloat(metricDecision.Value, 'f', -1, 64),
+			strconv.FormatFloat(metricDecision.Baseline, 'f', -1, 64),
+			strconv.FormatFloat(metricDecision.Threshold, 'f', -1, 64),
+			strconv.FormatFloat(metricDecision.Margin, 'f', -1, 64),
+			strconv.FormatBool(metricDecision.Passed))
+	}
+
+	return buffer
+}
+
+// Write the CSV format frames explanations report to the provided writer which can be a file reference.
+func ExportFramesExplanationsCsvReport(file io.Writer, explanations []FrameExplanation) error {
+	csvWriter := csv.NewWriter(file)
+	header := []string{
+		"Frame",
+		"Timestamp",
+		"Time",
+		"Status",
+		"Detected",
+		"PassedMetrics",
+		"Score",
+		"Tile",
+	}
+
+	for _, name := range frame.GetMetricsNames() {
+		header = append(header, name+"-value", name+"-baseline", name+"-threshold", name+"-margin", name+"-passed")
+	}
+
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("detector: failed to write the header to the explanations report file: %w", err)
+	}
+
+	for _, explanation := range explanations {
+		if err := csvWriter.Write(explanation.ToBuffer()); err != nil {
+			return fmt.Errorf("detector: failed to write the frame explanation to the explanations report file: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	return nil
+}
+
+// Write the JSON format frames explanations report to the provided writer which can be a file reference.
+func ExportFramesExplanationsJsonReport(file io.Writer, explanations []FrameExplanation) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(explanations); err != nil {
+		return fmt.Errorf("detector: failed to encode the frames explanations to json report file: %w", err)
+	}
+
+	return nil
+}
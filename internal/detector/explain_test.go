@@ -0,0 +1,144 @@
+package detector
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramesExplanationsShouldResolveFramesStatus(t *testing.T) {
+	frames := mockFrames(7)
+	decisions := []FrameDecision{
+		{Detected: true},
+		{Detected: false},
+		{Detected: true},
+		{Detected: false},
+		{Detected: false, Sustainable: true, Sustained: true},
+		{Detected: false, Sustainable: true, Sustained: true},
+		{Detected: false, SceneCut: true},
+	}
+
+	explanations := CreateFramesExplanations(frames, decisions, []int{1, 2, 5})
+
+	assert.Len(t, explanations, 7)
+	assert.Equal(t, PassedFrameStatus, explanations[0].Status)
+	assert.Equal(t, AddedFrameStatus, explanations[1].Status)
+	assert.Equal(t, RemovedFrameStatus, explanations[2].Status)
+	assert.Equal(t, FailedFrameStatus, explanations[3].Status)
+	assert.Equal(t, SustainedFrameStatus, explanations[4].Status)
+	assert.Equal(t, RemovedFrameStatus, explanations[5].Status)
+	assert.Equal(t, SceneCutFrameStatus, explanations[6].Status)
+
+	for index, explanation := range explanations {
+		assert.Equal(t, frames[index].OrdinalNumber, explanation.Frame)
+	}
+}
+
+func TestFramesExplanationsShouldExplainDetectionBufferGapFilling(t *testing.T) {
+	frames := mockFrames(6)
+	options := GetDefaultDetectorOptions()
+	for _, name := range frame.GetMetricsNames() {
+		options.SetMetricThreshold(name, 0.1)
+	}
+
+	for _, index := range []int{1, 3} {
+		for _, name := range frame.GetMetricsNames() {
+			frames[index].Metrics[name] = 0.5
+		}
+	}
+
+	statistics := func(name string) (float64, float64) {
+		return 0.0, 0.0
+	}
+
+	buffer := CreateDetectionBuffer()
+	decisions := make([]FrameDecision, 0, len(frames))
+	for frameIndex, f := range frames {
+		decision := decideFrame(options, f, statistics)
+		decisions = append(decisions, decision)
+		buffer.Append(frameIndex, decision.Detected)
+	}
+
+	detections := make([]int, 0)
+	for _, frameIndex := range buffer.Resolve() {
+		detections = append(detections, frames[frameIndex].OrdinalNumber)
+	}
+
+	explanations := CreateFramesExplanations(frames, decisions, detections)
+
+	statuses := make([]string, 0, len(explanations))
+	for _, explanation := range explanations {
+		statuses = append(statuses, explanation.Status)
+	}
+
+	assert.Equal(t, []string{
+		FailedFrameStatus,
+		PassedFrameStatus,
+		AddedFrameStatus,
+		PassedFrameStatus,
+		FailedFrameStatus,
+		FailedFrameStatus,
+	}, statuses)
+
+	assert.Equal(t, 0, explanations[2].PassedMetrics)
+	assert.InDelta(t, -0.1, explanations[2].Metrics[frame.BrightnessMetricName].Margin, 1e-9)
+	assert.InDelta(t, 0.4, explanations[3].Metrics[frame.BrightnessMetricName].Margin, 1e-9)
+}
+
+func TestFramesExplanationsShouldExportCsvReport(t *testing.T) {
+	frameTime := time.Date(2024, 6, 1, 21, 30, 5, 240000000, time.UTC)
+	explanations := []FrameExplanation{
+		{
+			Frame:         7,
+			Timestamp:     0.24,
+			Time:          &frameTime,
+			Status:        AddedFrameStatus,
+			Detected:      true,
+			PassedMetrics: 1,
+			Score:         -0.05,
+			Metrics: map[string]MetricDecision{
+				frame.BrightnessMetricName: {Value: 0.3, Baseline: 0.1, Threshold: 0.1, Limit: 0.2, Margin: 0.1, Passed: true},
+			},
+		},
+	}
+
+	buffer := bytes.Buffer{}
+	assert.Nil(t, ExportFramesExplanationsCsvReport(&buffer, explanations))
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+
+	header, record := records[0], records[1]
+	assert.Len(t, header, 8+5*len(frame.GetMetricsNames()))
+	assert.Len(t, record, len(header))
+	assert.Equal(t, []string{"7", "0.24", "2024-06-01T21:30:05.24Z", "added", "true", "1", "-0.05", "0"}, record[:8])
+
+	for index, column := range header {
+		if column == frame.BrightnessMetricName+"-margin" {
+			assert.Equal(t, "0.1", record[index])
+		}
+
+		if column == frame.BrightnessMetricName+"-passed" {
+			assert.Equal(t, "true", record[index])
+		}
+	}
+}
+
+func TestFramesExplanationsShouldExportJsonReport(t *testing.T) {
+	explanations := []FrameExplanation{
+		{Frame: 1, Status: FailedFrameStatus, Metrics: map[string]MetricDecision{}},
+	}
+
+	buffer := bytes.Buffer{}
+	assert.Nil(t, ExportFramesExplanationsJsonReport(&buffer, explanations))
+
+	decoded := []FrameExplanation{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Equal(t, explanations, decoded)
+}
@@ -0,0 +1,127 @@
+package detector
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+var regionOutlineColor color.RGBA = color.RGBA{255, 0, 0, 255}
+
+// Helper function used to localize the lightning strikes of the events by finding the largest regions of the detected frames which
+// changed compared to the reference frame directly preceding the event. The regions are stored on the frames of the collection in
+// the original frame coordinates and the regions of the peak frame are stored on the event.
+func (detector *detector) performStrikeLocalization(inputVideoPath string, framesCollection *frame.FramesCollection, result DetectionResult) error {
+	strikeLocalizationTime := time.Now()
+	detector.renderer.LogDebug("Starting the strike localization stage.")
+
+	video, err := detector.createFrameSource(inputVideoPath)
+	if err != nil {
+		return fmt.Errorf("detector: failed to open the frame source for the strike localization stage: %w", err)
+	}
+
+	defer video.Close()
+
+	targetWidth := int(float64(video.Width()) * detector.options.FrameScalingFactor)
+	targetHeight := int(float64(video.Height()) * detector.options.FrameScalingFactor)
+
+	frameMask, err := detector.createFrameMask(targetWidth, targetHeight)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the frame mask for the strike localization stage: %w", err)
+	}
+
+	var (
+		frameReference = image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+		frameCurrent   = image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+		scaleFactor    = 1.0 / detector.options.FrameScalingFactor
+		minArea        = int(float64(detector.options.LocalizationMinimumArea) * detector.options.FrameScalingFactor * detector.options.FrameScalingFactor)
+	)
+
+	progressBarStep, progressBarClose := detector.renderer.Progress("Strike localization stage.", len(result.Events))
+
+	for eventIndex := range result.Events {
+		event := &result.Events[eventIndex]
+
+		frameNumbers := getLightningEventCompositeFrames(*event, result.Detections)
+		if frameNumbers[0] == event.FirstFrame {
+			detector.renderer.LogWarning("Event: [%d/%d]. The event starts at the first frame and can not be localized without the reference frame.", eventIndex+1, len(result.Events))
+			progressBarStep()
+			continue
+		}
+
+		frameIndexes := make([]int, 0, len(frameNumbers))
+		for _, ordinalNumber := range frameNumbers {
+			frameIndexes = append(frameIndexes, ordinalNumber-1)
+		}
+
+		frames, err := video.ReadFrames(frameIndexes...)
+		if err != nil {
+			return fmt.Errorf("detector: failed to read the event frames from the video: %w", err)
+		}
+
+		if err := detector.prepareLocalizationFrame(frames[0], frameReference); err != nil {
+			return err
+		}
+
+		for index, ordinalNumber := range frameNumbers[1:] {
+			if err := detector.prepareLocalizationFrame(frames[index+1], frameCurrent); err != nil {
+				return err
+			}
+
+			regions := frame.FindChangedRegions(frameCurrent, frameReference, frameMask, minArea, int(detector.options.LocalizationRegionsLimit))
+			for regionIndex := range regions {
+				regions[regionIndex] = regions[regionIndex].Scale(scaleFactor)
+			}
+
+			if f, err := framesCollection.Get(ordinalNumber); err == nil {
+				f.Regions = regions
+			}
+
+			if ordinalNumber == event.PeakFrame {
+				event.Regions = regions
+			}
+		}
+
+		if len(event.Regions) != 0 {
+			region := event.Regions[0]
+			detector.renderer.LogDebug("Event: [%d/%d]. Peak region: %dx%d at (%d, %d) Centroid: (%.1f, %.1f) Area: %d", eventIndex+1, len(result.Events), region.Width, region.Height, region.X, region.Y, region.CentroidX, region.CentroidY, region.Area)
+		}
+
+		progressBarStep()
+	}
+
+	progressBarClose()
+	detector.renderer.LogDebug("Strike localization stage finished. Stage took: %s", time.Since(strikeLocalizationTime))
+	return nil
+}
+
+// Helper function used to scale and optionally denoise the frame image in the same way as on the analysis stage.
+func (detector *detector) prepareLocalizationFrame(src, dst *image.RGBA) error {
+	if err := utils.ScaleImage(src, dst, detector.options.FrameScalingFactor); err != nil {
+		return fmt.Errorf("detector: failed to scale the frame image on the strike localization stage: %w", err)
+	}
+
+	if detector.options.Denoise {
+		if err := utils.BlurImage(dst, dst, 8); err != nil {
+			return fmt.Errorf("detector: failed to blur the frame image on the strike localization stage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Helper function used to draw the outlines of the changed regions bounding boxes onto the frame image.
+func drawChangedRegions(img *image.RGBA, regions []frame.ChangedRegion) error {
+	thickness := utils.MaxInt(1, utils.MinInt(img.Bounds().Dx(), img.Bounds().Dy())/320)
+	for _, region := range regions {
+		if err := utils.DrawRectangle(img, region.Bounds(), regionOutlineColor, thickness); err != nil {
+			return fmt.Errorf("detector: failed to draw the changed region: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package detector
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+// Structure representing the compensation of the camera motion between the consecutive analyzed frames. The previous frame is
+// translated by the estimated global shift before the frames are compared, so the difference metrics are not affected by the
+// camera panning. The shift of the most recently compensated frame is stored in the original frame pixels.
+type motionCompensator struct {
+	enabled     bool
+	maxShift    int
+	scale       float64
+	compensated *image.RGBA
+	shiftX      float64
+	shiftY      float64
+}
+
+// Create the motion compensator of the scaled frames of the given size based on the motion compensation options.
+func createMotionCompensator(options DetectorOptions, width, height int) *motionCompensator {
+	compensator := &motionCompensator{
+		enabled:     options.MotionCompensation,
+		maxShift:    utils.MaxInt(1, int(float64(options.MotionMaxShift)*options.FrameScalingFactor)),
+		scale:       options.FrameScalingFactor,
+		compensated: nil,
+	}
+
+	if compensator.enabled {
+		compensator.compensated = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+
+	return compensator
+}
+
+// Estimate the shift of the current frame specified by the ordinal number relative to the previous frame and return the previous
+// frame translated by the shift. The previous frame is returned unchanged if the motion compensation is disabled or the current
+// frame is the first frame of the video. The returned frame is valid until the next call.
+func (compensator *motionCompensator) Compensate(frameCurrent, framePrevious *image.RGBA, frameNumber int) (*image.RGBA, error) {
+	compensator.shiftX, compensator.shiftY = 0, 0
+	if !compensator.enabled || frameNumber == 1 {
+		return framePrevious, nil
+	}
+
+	shiftX, shiftY, err := utils.EstimateTranslation(frameCurrent, framePrevious, compensator.maxShift)
+	if err != nil {
+		return nil, fmt.Errorf("detector: failed to estimate the frame shift: %w", err)
+	}
+
+	// NOTE: The pixels uncovered by the translation are filled with the current frame pixels, so they do not affect the
+	// difference metrics.
+	if err := utils.TranslateImage(framePrevious, compensator.compensated, frameCurrent, shiftX, shiftY); err != nil {
+		return nil, fmt.Errorf("detector: failed to translate the previous frame: %w", err)
+	}
+
+	compensator.shiftX = float64(shiftX) / compensator.scale
+	compensator.shiftY = float64(shiftY) / compensator.scale
+	return compensator.compensated, nil
+}
+
+// Assign the shift estimated by the most recent compensation to the frame.
+func (compensator *motionCompensator) Apply(f *frame.Frame) {
+	f.ShiftX = compensator.shiftX
+	f.ShiftY = compensator.shiftY
+}
@@ -0,0 +1,48 @@
+package detector
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMotionCompensatorShouldCompensateCameraPanning(t *testing.T) {
+	previous := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y += 1 {
+		for x := 0; x < 64; x += 1 {
+			previous.SetRGBA(x, y, color.RGBA{uint8((x * 37) ^ (y * 11)), uint8((x * 5) ^ (y * 23)), uint8(x * y), 255})
+		}
+	}
+
+	current := image.NewRGBA(previous.Rect)
+	assert.Nil(t, utils.TranslateImage(previous, current, previous, 3, -2))
+
+	options := GetDefaultDetectorOptions()
+	options.FrameScalingFactor = 0.5
+	options.MotionMaxShift = 16
+
+	compensator := createMotionCompensator(options, 64, 64)
+	compensated, err := compensator.Compensate(current, previous, 2)
+	assert.Nil(t, err)
+	assert.Same(t, previous, compensated)
+
+	options.MotionCompensation = true
+	compensator = createMotionCompensator(options, 64, 64)
+
+	compensated, err = compensator.Compensate(current, previous, 1)
+	assert.Nil(t, err)
+	assert.Same(t, previous, compensated)
+
+	compensated, err = compensator.Compensate(current, previous, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, current.Pix, compensated.Pix)
+
+	f := &frame.Frame{OrdinalNumber: 2}
+	compensator.Apply(f)
+	assert.Equal(t, 6.0, f.ShiftX)
+	assert.Equal(t, -4.0, f.ShiftY)
+}
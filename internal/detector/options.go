@@ -1,61 +1,527 @@
 package detector
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
 // Structure representing the options for the detector.
 type DetectorOptions struct {
-	AutoThresholds                              bool
-	BrightnessDetectionThreshold                float64
-	ColorDifferenceDetectionThreshold           float64
-	BinaryThresholdDifferenceDetectionThreshold float64
-	MovingMeanResolution                        int32
-	ExportCsvReport                             bool
-	ExportJsonReport                            bool
-	ExportChartReport                           bool
-	ExportTimingsReport                         bool
-	SkipFramesExport                            bool
-	Denoise                                     bool
-	FrameScalingFactor                          float64
+	AutoThresholds              bool               `json:"auto-thresholds" yaml:"auto-thresholds"`
+	AutoThresholdsStrategy      string             `json:"auto-thresholds-strategy" yaml:"auto-thresholds-strategy"`
+	AutoThresholdsMadMultiplier float64            `json:"auto-thresholds-mad-multiplier" yaml:"auto-thresholds-mad-multiplier"`
+	MetricThresholds            map[string]float64 `json:"metric-thresholds" yaml:"metric-thresholds"`
+	ExplicitThresholds          []string           `json:"explicit-thresholds" yaml:"explicit-thresholds"`
+	DetectionMode               string             `json:"detection-mode" yaml:"detection-mode"`
+	ZScoreSigma                 float64            `json:"z-score-sigma" yaml:"z-score-sigma"`
+	CombinationRule             string             `json:"combination-rule" yaml:"combination-rule"`
+	CombinationMinimumMetrics   int32              `json:"combination-minimum-metrics" yaml:"combination-minimum-metrics"`
+	CombinationScoreThreshold   float64            `json:"combination-score-threshold" yaml:"combination-score-threshold"`
+	MetricWeights               map[string]float64 `json:"metric-weights" yaml:"metric-weights"`
+	SustainThresholds           map[string]float64 `json:"sustain-thresholds" yaml:"sustain-thresholds"`
+	MovingMeanResolution        int32              `json:"moving-mean-resolution" yaml:"moving-mean-resolution"`
+	EventFramesGap              int32              `json:"event-frames-gap" yaml:"event-frames-gap"`
+	DetectionWindowLength       int32              `json:"detection-window-length" yaml:"detection-window-length"`
+	DetectionMaxGap             int32              `json:"detection-max-gap" yaml:"detection-max-gap"`
+	DetectionMinRunLength       int32              `json:"detection-min-run-length" yaml:"detection-min-run-length"`
+	DetectionMaxRunLength       int32              `json:"detection-max-run-length" yaml:"detection-max-run-length"`
+	StatisticsPercentiles       []float64          `json:"statistics-percentiles" yaml:"statistics-percentiles"`
+	ExportCsvReport             bool               `json:"export-csv-report" yaml:"export-csv-report"`
+	ExportJsonReport            bool               `json:"export-json-report" yaml:"export-json-report"`
+	ExportChartReport           bool               `json:"export-chart-report" yaml:"export-chart-report"`
+	ExportTimingsReport         bool               `json:"export-timings-report" yaml:"export-timings-report"`
+	ExportExplainReport         bool               `json:"export-explain-report" yaml:"export-explain-report"`
+	ExportAnalysisCache         bool               `json:"export-analysis-cache" yaml:"export-analysis-cache"`
+	AnalysisCachePath           string             `json:"analysis-cache-path" yaml:"analysis-cache-path"`
+	SkipFramesExport            bool               `json:"skip-frames-export" yaml:"skip-frames-export"`
+	Streaming                   bool               `json:"streaming" yaml:"streaming"`
+	RawStreamWidth              int32              `json:"raw-stream-width" yaml:"raw-stream-width"`
+	RawStreamHeight             int32              `json:"raw-stream-height" yaml:"raw-stream-height"`
+	RawStreamFPS                float64            `json:"raw-stream-fps" yaml:"raw-stream-fps"`
+	SequenceFPS                 float64            `json:"sequence-fps" yaml:"sequence-fps"`
+	ExportClips                 bool               `json:"export-clips" yaml:"export-clips"`
+	ClipPreRollFrames           int32              `json:"clip-pre-roll-frames" yaml:"clip-pre-roll-frames"`
+	ClipPostRollFrames          int32              `json:"clip-post-roll-frames" yaml:"clip-post-roll-frames"`
+	ExportComposite             bool               `json:"export-composite" yaml:"export-composite"`
+	ExportEventComposites       bool               `json:"export-event-composites" yaml:"export-event-composites"`
+	AnalysisStart               string             `json:"analysis-start" yaml:"analysis-start"`
+	AnalysisEnd                 string             `json:"analysis-end" yaml:"analysis-end"`
+	FrameStride                 int32              `json:"frame-stride" yaml:"frame-stride"`
+	WallClockStart              string             `json:"wall-clock-start" yaml:"wall-clock-start"`
+	WallClockTimezone           string             `json:"wall-clock-timezone" yaml:"wall-clock-timezone"`
+	LocalizeStrikes             bool               `json:"localize-strikes" yaml:"localize-strikes"`
+	LocalizationRegionsLimit    int32              `json:"localization-regions-limit" yaml:"localization-regions-limit"`
+	LocalizationMinimumArea     int32              `json:"localization-minimum-area" yaml:"localization-minimum-area"`
+	DrawRegions                 bool               `json:"draw-regions" yaml:"draw-regions"`
+	Denoise                     bool               `json:"denoise" yaml:"denoise"`
+	FrameScalingFactor          float64            `json:"frame-scaling-factor" yaml:"frame-scaling-factor"`
+	IncludeRegions              []string           `json:"include-regions" yaml:"include-regions"`
+	ExcludeRegions              []string           `json:"exclude-regions" yaml:"exclude-regions"`
+	MaskImagePath               string             `json:"mask-image-path" yaml:"mask-image-path"`
+	TileColumns                 int32              `json:"tile-columns" yaml:"tile-columns"`
+	TileRows                    int32              `json:"tile-rows" yaml:"tile-rows"`
+	MotionCompensation          bool               `json:"motion-compensation" yaml:"motion-compensation"`
+	MotionMaxShift              int32              `json:"motion-max-shift" yaml:"motion-max-shift"`
+	SceneCutDetection           bool               `json:"scene-cut-detection" yaml:"scene-cut-detection"`
+	SceneCutThreshold           float64            `json:"scene-cut-threshold" yaml:"scene-cut-threshold"`
 	// When true, suppress per-frame positive detection Info logs while keeping progress bars and summaries.
-	QuietDetections bool
+	QuietDetections bool `json:"quiet-detections" yaml:"quiet-detections"`
+}
+
+const (
+	ThresholdDetectionMode string = "threshold"
+	ZScoreDetectionMode    string = "z-score"
+
+	AllCombinationRule      string = "all"
+	AnyCombinationRule      string = "any"
+	KOfNCombinationRule     string = "k-of-n"
+	WeightedCombinationRule string = "weighted"
+
+	MeanDeviationAutoThresholdsStrategy string = "mean-deviation"
+	MadAutoThresholdsStrategy           string = "mad"
+
+	CreationTimeWallClockStart string = "creation-time"
+
+	UserThresholdSource    string = "user"
+	AutoThresholdSource    string = "auto"
+	DefaultThresholdSource string = "default"
+)
+
+// Return the detection threshold of the metric or the default threshold of the metric if it is not specified.
+func (options *DetectorOptions) GetMetricThreshold(name string) float64 {
+	if threshold, ok := options.MetricThresholds[name]; ok {
+		return threshold
+	}
+
+	return getDefaultMetricThreshold(name)
+}
+
+// Set the detection threshold of the frame metric specified by the name.
+func (options *DetectorOptions) SetMetricThreshold(name string, value float64) {
+	cloneOptions(options)
+	options.MetricThresholds[name] = value
+}
+
+// Mark the detection threshold of the metric as specified by the user, so it is not replaced by the auto-calculated one.
+func (options *DetectorOptions) SetMetricThresholdExplicit(name string) {
+	if options.IsMetricThresholdExplicit(name) {
+		return
+	}
+
+	cloneOptions(options)
+	options.ExplicitThresholds = append(options.ExplicitThresholds, name)
+}
+
+// Return a boolean value representing if the detection threshold of the metric was specified by the user.
+func (options *DetectorOptions) IsMetricThresholdExplicit(name string) bool {
+	for _, explicitName := range options.ExplicitThresholds {
+		if explicitName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the source of the detection threshold of the metric: the user, the auto thresholds or the default value.
+func (options *DetectorOptions) GetMetricThresholdSource(name string) string {
+	switch {
+	case options.IsMetricThresholdExplicit(name):
+		return UserThresholdSource
+	case options.AutoThresholds:
+		return AutoThresholdSource
+	default:
+		return DefaultThresholdSource
+	}
+}
+
+// Return the weight of the metric used by the weighted combination rule, which is equal to one if not specified.
+func (options *DetectorOptions) GetMetricWeight(name string) float64 {
+	if weight, ok := options.MetricWeights[name]; ok {
+		return weight
+	}
+
+	return 1.0
+}
+
+// Set the weight of the frame metric specified by the name used by the weighted combination rule.
+func (options *DetectorOptions) SetMetricWeight(name string, weight float64) {
+	cloneOptions(options)
+	options.MetricWeights[name] = weight
+}
+
+// Return the sustain threshold of the metric and a boolean value representing if the threshold is specified.
+func (options *DetectorOptions) GetMetricSustainThreshold(name string) (float64, bool) {
+	threshold, ok := options.SustainThresholds[name]
+	return threshold, ok
+}
+
+// Set the sustain threshold of the frame metric specified by the name.
+func (options *DetectorOptions) SetMetricSustainThreshold(name string, value float64) {
+	cloneOptions(options)
+	options.SustainThresholds[name] = value
+}
+
+// Return a boolean value representing if the input is read as the stream of raw RGBA frames.
+func (options *DetectorOptions) IsRawStream() bool {
+	return options.RawStreamWidth != 0 || options.RawStreamHeight != 0
+}
+
+// Return a boolean value representing if the hysteresis detection is enabled by any sustain threshold.
+func (options *DetectorOptions) IsHysteresisEnabled() bool {
+	return len(options.SustainThresholds) != 0
+}
+
+// Helper function used to copy the metrics maps and slices of the options before they are modified, because the copies of the
+// options are sharing them.
+func cloneOptions(options *DetectorOptions) {
+	options.MetricThresholds = copyMetricsValues(options.MetricThresholds)
+	options.MetricWeights = copyMetricsValues(options.MetricWeights)
+	options.SustainThresholds = copyMetricsValues(options.SustainThresholds)
+	options.ExplicitThresholds = append([]string{}, options.ExplicitThresholds...)
 }
 
 // Return a boolean value representing if the detector options are valid. If any validation errors occured
 // a message will be stored in the string return value.
 // TODO: MovingMeanResolution validation >1
 func (options *DetectorOptions) AreValid() (bool, string) {
-	if options.BrightnessDetectionThreshold < 0.0 || options.BrightnessDetectionThreshold > 1.0 {
-		return false, "the frame brightness detection threshold must be between zero and one"
+	for _, name := range frame.GetMetricsNames() {
+		if threshold := options.GetMetricThreshold(name); threshold < 0.0 || threshold > 1.0 {
+			return false, fmt.Sprintf("the frame %s detection threshold must be between zero and one", name)
+		}
+	}
+
+	for name := range options.MetricThresholds {
+		if !frame.IsMetricName(name) {
+			return false, fmt.Sprintf("the detection threshold is specified for the unknown %s metric", name)
+		}
+	}
+
+	for _, name := range options.ExplicitThresholds {
+		if !frame.IsMetricName(name) {
+			return false, fmt.Sprintf("the explicit threshold is specified for the unknown %s metric", name)
+		}
+	}
+
+	if options.DetectionMode != ThresholdDetectionMode && options.DetectionMode != ZScoreDetectionMode {
+		return false, fmt.Sprintf("the detection mode %q is invalid", options.DetectionMode)
+	}
+
+	if options.ZScoreSigma <= 0.0 {
+		return false, "the z-score sigma must be positive"
+	}
+
+	switch options.CombinationRule {
+	case AllCombinationRule, AnyCombinationRule, KOfNCombinationRule, WeightedCombinationRule:
+	default:
+		return false, fmt.Sprintf("the combination rule %q is invalid", options.CombinationRule)
+	}
+
+	if options.CombinationMinimumMetrics < 1 || int(options.CombinationMinimumMetrics) > len(frame.GetMetricsNames()) {
+		return false, fmt.Sprintf("the combination minimum metrics must be between one and %d", len(frame.GetMetricsNames()))
 	}
 
-	if options.ColorDifferenceDetectionThreshold < 0.0 || options.ColorDifferenceDetectionThreshold > 1.0 {
-		return false, "the frame color difference detection threshold must be between zero and one"
+	weightsSum := 0.0
+	for _, name := range frame.GetMetricsNames() {
+		weightsSum += options.GetMetricWeight(name)
 	}
 
-	if options.BinaryThresholdDifferenceDetectionThreshold < 0.0 || options.BinaryThresholdDifferenceDetectionThreshold > 1.0 {
-		return false, "the frame binary threshold difference detection threshold must be between zero and one"
+	for name, weight := range options.MetricWeights {
+		if !frame.IsMetricName(name) {
+			return false, fmt.Sprintf("the weight is specified for the unknown %s metric", name)
+		}
+
+		if weight < 0.0 {
+			return false, fmt.Sprintf("the %s metric weight must not be negative", name)
+		}
+	}
+
+	if weightsSum <= 0.0 {
+		return false, "the sum of the metrics weights must be positive"
+	}
+
+	for name, threshold := range options.SustainThresholds {
+		if !frame.IsMetricName(name) {
+			return false, fmt.Sprintf("the sustain threshold is specified for the unknown %s metric", name)
+		}
+
+		if threshold < 0.0 {
+			return false, fmt.Sprintf("the %s metric sustain threshold must not be negative", name)
+		}
+
+		if options.DetectionMode == ThresholdDetectionMode && threshold > 1.0 {
+			return false, fmt.Sprintf("the %s metric sustain threshold must be between zero and one", name)
+		}
+	}
+
+	if options.AutoThresholdsStrategy != MeanDeviationAutoThresholdsStrategy && options.AutoThresholdsStrategy != MadAutoThresholdsStrategy {
+		return false, fmt.Sprintf("the auto thresholds strategy %q is invalid", options.AutoThresholdsStrategy)
+	}
+
+	if options.AutoThresholdsMadMultiplier <= 0.0 {
+		return false, "the auto thresholds median absolute deviation multiplier must be positive"
+	}
+
+	for _, percentile := range options.StatisticsPercentiles {
+		if percentile < 0.0 || percentile > 100.0 {
+			return false, "the statistics percentiles must be between zero and one hundred"
+		}
+	}
+
+	if options.EventFramesGap < 0 {
+		return false, "the event frames gap must not be negative"
+	}
+
+	if options.DetectionWindowLength < 2 {
+		return false, "the detection window length must be at least two"
+	}
+
+	if options.DetectionMaxGap < 0 || options.DetectionMaxGap > options.DetectionWindowLength-2 {
+		return false, "the detection maximal gap must not be negative and must fit between two detections in the detection window"
+	}
+
+	if options.DetectionMinRunLength < 1 {
+		return false, "the detection minimal run length must be positive"
+	}
+
+	if options.DetectionMaxRunLength < 0 || (options.DetectionMaxRunLength != 0 && options.DetectionMaxRunLength < options.DetectionMinRunLength) {
+		return false, "the detection maximal run length must be zero or not lower than the minimal run length"
+	}
+
+	if options.ClipPreRollFrames < 0 || options.ClipPostRollFrames < 0 {
+		return false, "the clip pre-roll and post-roll frames must not be negative"
 	}
 
 	if options.FrameScalingFactor < 0.0 || options.FrameScalingFactor > 1.0 {
 		return false, "the scaling factor must be between zero and one"
 	}
 
+	if options.FrameStride < 1 {
+		return false, "the frame stride must be positive"
+	}
+
+	analysisStart := framePosition{frame: 1}
+	if len(options.AnalysisStart) != 0 {
+		position, err := parseFramePosition(options.AnalysisStart)
+		if err != nil {
+			return false, fmt.Sprintf("the analysis start %q is invalid: %s", options.AnalysisStart, err)
+		}
+
+		analysisStart = position
+	}
+
+	if len(options.AnalysisEnd) != 0 {
+		analysisEnd, err := parseFramePosition(options.AnalysisEnd)
+		if err != nil {
+			return false, fmt.Sprintf("the analysis end %q is invalid: %s", options.AnalysisEnd, err)
+		}
+
+		if analysisStart.timestamp == analysisEnd.timestamp && (analysisEnd.frame < analysisStart.frame || analysisEnd.seconds < analysisStart.seconds) {
+			return false, "the analysis end must not precede the analysis start"
+		}
+
+		if analysisEnd.timestamp && options.IsRawStream() && options.RawStreamFPS == 0.0 {
+			return false, "the analysis range timestamps require the raw stream frame rate"
+		}
+	}
+
+	if analysisStart.timestamp && options.IsRawStream() && options.RawStreamFPS == 0.0 {
+		return false, "the analysis range timestamps require the raw stream frame rate"
+	}
+
+	if _, err := time.LoadLocation(options.WallClockTimezone); err != nil {
+		return false, fmt.Sprintf("the wall-clock time zone %q is invalid: %s", options.WallClockTimezone, err)
+	}
+
+	if len(options.WallClockStart) != 0 {
+		if options.WallClockStart != CreationTimeWallClockStart {
+			if _, err := parseWallClockStart(options.WallClockStart, options.WallClockTimezone); err != nil {
+				return false, fmt.Sprintf("the wall-clock start is invalid: %s", err)
+			}
+		}
+
+		if options.IsRawStream() && (options.WallClockStart == CreationTimeWallClockStart || options.RawStreamFPS == 0.0) {
+			return false, "the wall-clock time of the raw stream frames requires the explicit wall-clock start and the raw stream frame rate"
+		}
+	}
+
+	if options.TileColumns < 1 || options.TileRows < 1 {
+		return false, "the tiles grid columns and rows must be positive"
+	}
+
+	if options.MotionMaxShift < 1 {
+		return false, "the motion compensation maximal shift must be positive"
+	}
+
+	if options.SceneCutThreshold <= 0.0 || options.SceneCutThreshold > 1.0 {
+		return false, "the scene cut threshold must be greater than zero and not greater than one"
+	}
+
+	if options.LocalizationRegionsLimit < 1 {
+		return false, "the localization regions limit must be positive"
+	}
+
+	if options.LocalizationMinimumArea < 0 {
+		return false, "the localization minimum area must not be negative"
+	}
+
+	if options.DrawRegions && !options.LocalizeStrikes {
+		return false, "the regions drawing requires the strike localization"
+	}
+
+	if options.Streaming {
+		if options.AutoThresholds {
+			return false, "the auto thresholds require the whole video analysis and can not be used in the streaming mode"
+		}
+
+		if len(options.AnalysisCachePath) != 0 || options.ExportAnalysisCache {
+			return false, "the analysis cache can not be used in the streaming mode"
+		}
+
+		if options.ExportCsvReport || options.ExportJsonReport || options.ExportChartReport {
+			return false, "the frames reports require the whole video analysis and can not be exported in the streaming mode"
+		}
+
+		if options.ExportExplainReport {
+			return false, "the explain report requires the decisions of all frames and can not be exported in the streaming mode"
+		}
+
+		if options.LocalizeStrikes {
+			return false, "the strike localization requires reading the frames again and can not be performed in the streaming mode"
+		}
+
+		if options.SceneCutDetection {
+			return false, "the scene cut detection requires the frames following the detected frame and can not be performed in the streaming mode"
+		}
+	}
+
+	if options.SequenceFPS < 0.0 {
+		return false, "the image sequence frame rate must not be negative"
+	}
+
+	if options.IsRawStream() {
+		if options.RawStreamWidth <= 0 || options.RawStreamHeight <= 0 {
+			return false, "the raw stream frame width and height must be positive"
+		}
+
+		if options.RawStreamFPS < 0.0 {
+			return false, "the raw stream frame rate must not be negative"
+		}
+
+		if !options.Streaming {
+			return false, "the raw stream can be read only once and requires the streaming mode"
+		}
+
+		if options.ExportClips || options.ExportComposite || options.ExportEventComposites {
+			return false, "the clips and the composite images require reading the frames again and can not be exported from the raw stream"
+		}
+	}
+
+	for _, region := range options.IncludeRegions {
+		if _, err := frame.ParseRegion(region); err != nil {
+			return false, fmt.Sprintf("the include region %q is invalid: %s", region, err)
+		}
+	}
+
+	for _, region := range options.ExcludeRegions {
+		if _, err := frame.ParseRegion(region); err != nil {
+			return false, fmt.Sprintf("the exclude region %q is invalid: %s", region, err)
+		}
+	}
+
 	return true, ""
 }
 
+func getDefaultMetricThresholds() map[string]float64 {
+	thresholds := make(map[string]float64)
+	for _, metric := range frame.GetMetrics() {
+		thresholds[metric.Name()] = metric.DefaultThreshold()
+	}
+
+	return thresholds
+}
+
+func getDefaultMetricThreshold(name string) float64 {
+	for _, metric := range frame.GetMetrics() {
+		if metric.Name() == name {
+			return metric.DefaultThreshold()
+		}
+	}
+
+	return 0.0
+}
+
+func getDefaultMetricWeights() map[string]float64 {
+	weights := make(map[string]float64)
+	for _, name := range frame.GetMetricsNames() {
+		weights[name] = 1.0
+	}
+
+	return weights
+}
+
 // Return the default detector options.
 func GetDefaultDetectorOptions() DetectorOptions {
 	return DetectorOptions{
-		AutoThresholds:                              false,
-		BrightnessDetectionThreshold:                0.0,
-		ColorDifferenceDetectionThreshold:           0.0,
-		BinaryThresholdDifferenceDetectionThreshold: 0.0,
-		MovingMeanResolution:                        50,
-		ExportCsvReport:                             false,
-		ExportJsonReport:                            false,
-		ExportChartReport:                           false,
-		ExportTimingsReport:                         false,
-		SkipFramesExport:                            false,
-		Denoise:                                     false,
-		FrameScalingFactor:                          0.5,
-		QuietDetections:                             false,
+		AutoThresholds:              false,
+		AutoThresholdsStrategy:      MeanDeviationAutoThresholdsStrategy,
+		AutoThresholdsMadMultiplier: 3.0,
+		MetricThresholds:            getDefaultMetricThresholds(),
+		ExplicitThresholds:          []string{},
+		DetectionMode:               ThresholdDetectionMode,
+		ZScoreSigma:                 3.0,
+		CombinationRule:             AllCombinationRule,
+		CombinationMinimumMetrics:   2,
+		CombinationScoreThreshold:   0.0,
+		MetricWeights:               getDefaultMetricWeights(),
+		SustainThresholds:           map[string]float64{},
+		MovingMeanResolution:        50,
+		EventFramesGap:              2,
+		DetectionWindowLength:       int32(defaultCandidatesBufferSize),
+		DetectionMaxGap:             int32(defaultMaxFilledGap),
+		DetectionMinRunLength:       1,
+		DetectionMaxRunLength:       0,
+		StatisticsPercentiles:       []float64{90, 95, 99},
+		ExportCsvReport:             false,
+		ExportJsonReport:            false,
+		ExportChartReport:           false,
+		ExportTimingsReport:         false,
+		ExportExplainReport:         false,
+		ExportAnalysisCache:         false,
+		AnalysisCachePath:           "",
+		SkipFramesExport:            false,
+		Streaming:                   false,
+		RawStreamWidth:              0,
+		RawStreamHeight:             0,
+		RawStreamFPS:                0.0,
+		SequenceFPS:                 0.0,
+		ExportClips:                 false,
+		ClipPreRollFrames:           15,
+		ClipPostRollFrames:          15,
+		ExportComposite:             false,
+		ExportEventComposites:       false,
+		AnalysisStart:               "",
+		AnalysisEnd:                 "",
+		FrameStride:                 1,
+		WallClockStart:              "",
+		WallClockTimezone:           "UTC",
+		LocalizeStrikes:             false,
+		LocalizationRegionsLimit:    3,
+		LocalizationMinimumArea:     16,
+		DrawRegions:                 false,
+		Denoise:                     false,
+		FrameScalingFactor:          0.5,
+		IncludeRegions:              []string{},
+		ExcludeRegions:              []string{},
+		MaskImagePath:               "",
+		TileColumns:                 1,
+		TileRows:                    1,
+		MotionCompensation:          false,
+		MotionMaxShift:              32,
+		SceneCutDetection:           false,
+		SceneCutThreshold:           0.4,
+		QuietDetections:             false,
 	}
 }
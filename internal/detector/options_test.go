@@ -3,6 +3,7 @@ package detector
 import (
 	"testing"
 
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,7 +20,7 @@ func TestShouldNotValidateInvalidBrightnessDetectionThreshold(t *testing.T) {
 
 	for _, value := range cases {
 		options := GetDefaultDetectorOptions()
-		options.BrightnessDetectionThreshold = value
+		options.SetMetricThreshold(frame.BrightnessMetricName, value)
 
 		valid, msg := options.AreValid()
 		assert.False(t, valid)
@@ -32,7 +33,7 @@ func TestShouldNotValidateInvalidColorDifferenceDetectionThreshold(t *testing.T)
 
 	for _, value := range cases {
 		options := GetDefaultDetectorOptions()
-		options.ColorDifferenceDetectionThreshold = value
+		options.SetMetricThreshold(frame.ColorDifferenceMetricName, value)
 
 		valid, msg := options.AreValid()
 		assert.False(t, valid)
@@ -45,7 +46,7 @@ func TestShouldNotValidateInvalidBinaryThresholdDetectionThreshold(t *testing.T)
 
 	for _, value := range cases {
 		options := GetDefaultDetectorOptions()
-		options.BinaryThresholdDifferenceDetectionThreshold = value
+		options.SetMetricThreshold(frame.BinaryThresholdDifferenceMetricName, value)
 
 		valid, msg := options.AreValid()
 		assert.False(t, valid)
@@ -65,3 +66,467 @@ func TestShouldNotValidateInvalidFrameScalingFactor(t *testing.T) {
 		assert.NotEmpty(t, msg)
 	}
 }
+
+func TestShouldNotValidateInvalidRegions(t *testing.T) {
+	cases := []string{"", "1,2,3", "1,2,0,4", "a,b,c,d", "1,2;3,4", "1,2;3;4,5"}
+
+	for _, value := range cases {
+		options := GetDefaultDetectorOptions()
+		options.IncludeRegions = []string{value}
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+
+		options = GetDefaultDetectorOptions()
+		options.ExcludeRegions = []string{value}
+
+		valid, msg = options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+}
+
+func TestShouldValidateRegions(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.IncludeRegions = []string{"0,0,100,50"}
+	options.ExcludeRegions = []string{"10,10;20,10;20,20", "0, 0, 5, 5"}
+
+	valid, msg := options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotValidateStreamingWithWholeVideoOptions(t *testing.T) {
+	modifiers := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.AutoThresholds = true },
+		func(options *DetectorOptions) { options.AnalysisCachePath = "analysis-cache.json" },
+		func(options *DetectorOptions) { options.ExportAnalysisCache = true },
+		func(options *DetectorOptions) { options.ExportCsvReport = true },
+		func(options *DetectorOptions) { options.ExportJsonReport = true },
+		func(options *DetectorOptions) { options.ExportChartReport = true },
+		func(options *DetectorOptions) { options.ExportExplainReport = true },
+	}
+
+	for _, modifier := range modifiers {
+		options := GetDefaultDetectorOptions()
+		options.Streaming = true
+		modifier(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+}
+
+func TestShouldNotValidateInvalidRawStreamOptions(t *testing.T) {
+	modifiers := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.Streaming = false },
+		func(options *DetectorOptions) { options.RawStreamHeight = 0 },
+		func(options *DetectorOptions) { options.RawStreamWidth = -1 },
+		func(options *DetectorOptions) { options.RawStreamFPS = -1 },
+		func(options *DetectorOptions) { options.ExportClips = true },
+		func(options *DetectorOptions) { options.ExportComposite = true },
+		func(options *DetectorOptions) { options.ExportEventComposites = true },
+	}
+
+	for _, modifier := range modifiers {
+		options := GetDefaultDetectorOptions()
+		options.Streaming = true
+		options.RawStreamWidth = 640
+		options.RawStreamHeight = 480
+		modifier(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.Streaming = true
+	options.RawStreamWidth = 640
+	options.RawStreamHeight = 480
+	options.RawStreamFPS = 30
+
+	valid, msg := options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+	assert.True(t, options.IsRawStream())
+}
+
+func TestShouldResolveMetricThresholdSource(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.SetMetricThresholdExplicit(frame.BrightnessMetricName)
+	options.SetMetricThresholdExplicit(frame.BrightnessMetricName)
+
+	assert.Equal(t, []string{frame.BrightnessMetricName}, options.ExplicitThresholds)
+	assert.True(t, options.IsMetricThresholdExplicit(frame.BrightnessMetricName))
+	assert.False(t, options.IsMetricThresholdExplicit(frame.ColorDifferenceMetricName))
+
+	assert.Equal(t, UserThresholdSource, options.GetMetricThresholdSource(frame.BrightnessMetricName))
+	assert.Equal(t, DefaultThresholdSource, options.GetMetricThresholdSource(frame.ColorDifferenceMetricName))
+
+	options.AutoThresholds = true
+	assert.Equal(t, UserThresholdSource, options.GetMetricThresholdSource(frame.BrightnessMetricName))
+	assert.Equal(t, AutoThresholdSource, options.GetMetricThresholdSource(frame.ColorDifferenceMetricName))
+}
+
+func TestShouldNotShareExplicitThresholdsBetweenOptionsCopies(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.SetMetricThresholdExplicit(frame.BrightnessMetricName)
+
+	other := options
+	other.SetMetricThresholdExplicit(frame.ColorDifferenceMetricName)
+
+	assert.False(t, options.IsMetricThresholdExplicit(frame.ColorDifferenceMetricName))
+	assert.True(t, other.IsMetricThresholdExplicit(frame.ColorDifferenceMetricName))
+}
+
+func TestShouldNotValidateUnknownExplicitThreshold(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.ExplicitThresholds = []string{"unknown"}
+
+	valid, msg := options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+
+	options = GetDefaultDetectorOptions()
+	options.SetMetricThreshold("unknown", 0.1)
+
+	valid, msg = options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+}
+
+func TestShouldResolveMetricThresholdsByMetricsNames(t *testing.T) {
+	defaultOptions := GetDefaultDetectorOptions()
+	for _, metric := range frame.GetMetrics() {
+		assert.Equal(t, metric.DefaultThreshold(), defaultOptions.GetMetricThreshold(metric.Name()))
+	}
+
+	options := defaultOptions
+	options.SetMetricThreshold(frame.BrightnessMetricName, 0.25)
+
+	assert.Equal(t, 0.25, options.GetMetricThreshold(frame.BrightnessMetricName))
+	assert.Equal(t, 0.0, defaultOptions.GetMetricThreshold(frame.BrightnessMetricName))
+
+	options.MetricThresholds = map[string]float64{}
+	assert.Equal(t, 0.0, options.GetMetricThreshold(frame.ColorDifferenceMetricName))
+}
+
+func TestShouldNotValidateInvalidAutoThresholdsOptions(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.AutoThresholdsStrategy = "unknown"
+
+	valid, msg := options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+
+	options = GetDefaultDetectorOptions()
+	options.AutoThresholdsMadMultiplier = 0
+
+	valid, msg = options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+}
+
+func TestShouldNotValidateInvalidStatisticsPercentiles(t *testing.T) {
+	cases := [][]float64{{-1}, {50, 100.5}}
+
+	for _, percentiles := range cases {
+		options := GetDefaultDetectorOptions()
+		options.StatisticsPercentiles = percentiles
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+}
+
+func TestShouldNotValidateInvalidDetectionModeOptions(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.DetectionMode = "unknown"
+
+	valid, msg := options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+
+	options = GetDefaultDetectorOptions()
+	options.DetectionMode = ZScoreDetectionMode
+	options.ZScoreSigma = -1
+
+	valid, msg = options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+}
+
+func TestShouldNotValidateInvalidCombinationOptions(t *testing.T) {
+	cases := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.CombinationRule = "unknown" },
+		func(options *DetectorOptions) { options.CombinationMinimumMetrics = 0 },
+		func(options *DetectorOptions) { options.CombinationMinimumMetrics = 4 },
+		func(options *DetectorOptions) { options.SetMetricWeight("unknown", 1.0) },
+		func(options *DetectorOptions) { options.SetMetricWeight(frame.BrightnessMetricName, -1.0) },
+		func(options *DetectorOptions) {
+			for _, name := range frame.GetMetricsNames() {
+				options.SetMetricWeight(name, 0.0)
+			}
+		},
+	}
+
+	for _, modify := range cases {
+		options := GetDefaultDetectorOptions()
+		modify(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+}
+
+func TestShouldNotValidateInvalidDetectionBufferOptions(t *testing.T) {
+	cases := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.DetectionWindowLength = 1 },
+		func(options *DetectorOptions) { options.DetectionMaxGap = -1 },
+		func(options *DetectorOptions) { options.DetectionMaxGap = 3 },
+		func(options *DetectorOptions) { options.DetectionMinRunLength = 0 },
+		func(options *DetectorOptions) { options.DetectionMaxRunLength = -1 },
+		func(options *DetectorOptions) {
+			options.DetectionMinRunLength = 3
+			options.DetectionMaxRunLength = 2
+		},
+	}
+
+	for _, modify := range cases {
+		options := GetDefaultDetectorOptions()
+		modify(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+}
+
+func TestShouldNotValidateInvalidSustainThresholds(t *testing.T) {
+	cases := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.SetMetricSustainThreshold("unknown", 0.1) },
+		func(options *DetectorOptions) { options.SetMetricSustainThreshold(frame.BrightnessMetricName, -0.1) },
+		func(options *DetectorOptions) { options.SetMetricSustainThreshold(frame.BrightnessMetricName, 1.1) },
+	}
+
+	for _, modify := range cases {
+		options := GetDefaultDetectorOptions()
+		modify(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.DetectionMode = ZScoreDetectionMode
+	options.SetMetricSustainThreshold(frame.BrightnessMetricName, 1.5)
+
+	valid, msg := options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotShareSustainThresholdsBetweenOptionsCopies(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	assert.False(t, options.IsHysteresisEnabled())
+
+	copied := options
+	copied.SetMetricSustainThreshold(frame.BrightnessMetricName, 0.1)
+
+	assert.True(t, copied.IsHysteresisEnabled())
+	assert.False(t, options.IsHysteresisEnabled())
+
+	threshold, ok := copied.GetMetricSustainThreshold(frame.BrightnessMetricName)
+	assert.True(t, ok)
+	assert.Equal(t, 0.1, threshold)
+
+	_, ok = copied.GetMetricSustainThreshold(frame.ColorDifferenceMetricName)
+	assert.False(t, ok)
+}
+
+func TestShouldNotShareMetricWeightsBetweenOptionsCopies(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	other := options
+	other.SetMetricWeight(frame.BrightnessMetricName, 2.0)
+
+	assert.Equal(t, 1.0, options.GetMetricWeight(frame.BrightnessMetricName))
+	assert.Equal(t, 2.0, other.GetMetricWeight(frame.BrightnessMetricName))
+
+	options.MetricWeights = nil
+	assert.Equal(t, 1.0, options.GetMetricWeight(frame.BrightnessMetricName))
+}
+
+func TestShouldNotValidateInvalidAnalysisRangeOptions(t *testing.T) {
+	cases := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.FrameStride = 0 },
+		func(options *DetectorOptions) { options.AnalysisStart = "0" },
+		func(options *DetectorOptions) { options.AnalysisEnd = "10m" },
+		func(options *DetectorOptions) {
+			options.AnalysisStart = "100"
+			options.AnalysisEnd = "50"
+		},
+		func(options *DetectorOptions) {
+			options.AnalysisStart = "01:00"
+			options.AnalysisEnd = "30s"
+		},
+		func(options *DetectorOptions) {
+			options.Streaming = true
+			options.RawStreamWidth = 2
+			options.RawStreamHeight = 2
+			options.AnalysisStart = "10s"
+		},
+	}
+
+	for _, modify := range cases {
+		options := GetDefaultDetectorOptions()
+		modify(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.AnalysisStart = "00:10"
+	options.AnalysisEnd = "500"
+	options.FrameStride = 5
+
+	valid, msg := options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotValidateInvalidWallClockOptions(t *testing.T) {
+	cases := []func(options *DetectorOptions){
+		func(options *DetectorOptions) { options.WallClockTimezone = "Mars/Olympus" },
+		func(options *DetectorOptions) { options.WallClockStart = "21:30" },
+		func(options *DetectorOptions) {
+			options.Streaming = true
+			options.RawStreamWidth = 2
+			options.RawStreamHeight = 2
+			options.RawStreamFPS = 25
+			options.WallClockStart = CreationTimeWallClockStart
+		},
+		func(options *DetectorOptions) {
+			options.Streaming = true
+			options.RawStreamWidth = 2
+			options.RawStreamHeight = 2
+			options.WallClockStart = "2024-06-01 21:30:05"
+		},
+		func(options *DetectorOptions) { options.SequenceFPS = -1 },
+	}
+
+	for _, modify := range cases {
+		options := GetDefaultDetectorOptions()
+		modify(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.WallClockStart = "2024-06-01T21:30:05+02:00"
+	options.WallClockTimezone = "Europe/Warsaw"
+
+	valid, msg := options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotValidateInvalidStrikeLocalizationOptions(t *testing.T) {
+	cases := []func(options *DetectorOptions){
+		func(options *DetectorOptions) {
+			options.LocalizeStrikes = true
+			options.LocalizationRegionsLimit = 0
+		},
+		func(options *DetectorOptions) {
+			options.LocalizeStrikes = true
+			options.LocalizationMinimumArea = -1
+		},
+		func(options *DetectorOptions) {
+			options.DrawRegions = true
+		},
+		func(options *DetectorOptions) {
+			options.Streaming = true
+			options.LocalizeStrikes = true
+		},
+	}
+
+	for _, modify := range cases {
+		options := GetDefaultDetectorOptions()
+		modify(&options)
+
+		valid, msg := options.AreValid()
+		assert.False(t, valid)
+		assert.NotEmpty(t, msg)
+	}
+
+	options := GetDefaultDetectorOptions()
+	options.LocalizeStrikes = true
+	options.DrawRegions = true
+
+	valid, msg := options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotValidateInvalidTilesGrid(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.TileColumns = 0
+
+	valid, msg := options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+
+	options.TileColumns = 4
+	options.TileRows = 3
+
+	valid, msg = options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotValidateInvalidMotionCompensationOptions(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.MotionCompensation = true
+	options.MotionMaxShift = 0
+
+	valid, msg := options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+
+	options.MotionMaxShift = 48
+
+	valid, msg = options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+}
+
+func TestShouldNotValidateInvalidSceneCutDetectionOptions(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.SceneCutDetection = true
+	options.SceneCutThreshold = 0.0
+
+	valid, msg := options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+
+	options.SceneCutThreshold = 0.5
+
+	valid, msg = options.AreValid()
+	assert.True(t, valid)
+	assert.Empty(t, msg)
+
+	options.Streaming = true
+
+	valid, msg = options.AreValid()
+	assert.False(t, valid)
+	assert.NotEmpty(t, msg)
+}
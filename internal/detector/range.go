@@ -0,0 +1,238 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/source"
+)
+
+// Structure representing the position of a video frame specified either by the frame ordinal number or by the timestamp.
+type framePosition struct {
+	frame     int
+	seconds   float64
+	timestamp bool
+}
+
+// Helper function used to parse the position of the frame specified by the ordinal number (e.g. 1500) or by the timestamp. The
+// timestamps are specified as the seconds with the "s" suffix (e.g. 90.5s) or in the [hh:]mm:ss[.fff] format (e.g. 01:30.5).
+func parseFramePosition(value string) (framePosition, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return framePosition{}, errors.New("detector: the frame position is empty")
+	}
+
+	if seconds, ok := strings.CutSuffix(value, "s"); ok {
+		parsedSeconds, err := strconv.ParseFloat(seconds, 64)
+		if err != nil || parsedSeconds < 0 || math.IsInf(parsedSeconds, 0) || math.IsNaN(parsedSeconds) {
+			return framePosition{}, fmt.Errorf("detector: invalid frame position timestamp seconds %q", value)
+		}
+
+		return framePosition{seconds: parsedSeconds, timestamp: true}, nil
+	}
+
+	if strings.Contains(value, ":") {
+		parts := strings.Split(value, ":")
+		if len(parts) > 3 {
+			return framePosition{}, fmt.Errorf("detector: invalid frame position timestamp %q", value)
+		}
+
+		seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+		if err != nil || seconds < 0 || seconds >= 60 {
+			return framePosition{}, fmt.Errorf("detector: invalid frame position timestamp seconds %q", value)
+		}
+
+		multiplier := 60.0
+		for index := len(parts) - 2; index >= 0; index -= 1 {
+			units, err := strconv.ParseUint(parts[index], 10, 32)
+			if err != nil || (index != 0 && units >= 60) {
+				return framePosition{}, fmt.Errorf("detector: invalid frame position timestamp %q", value)
+			}
+
+			seconds += float64(units) * multiplier
+			multiplier *= 60
+		}
+
+		return framePosition{seconds: seconds, timestamp: true}, nil
+	}
+
+	frameNumber, err := strconv.Atoi(value)
+	if err != nil || frameNumber < 1 {
+		return framePosition{}, fmt.Errorf("detector: invalid frame position %q, expected a positive frame number or a timestamp", value)
+	}
+
+	return framePosition{frame: frameNumber}, nil
+}
+
+// Convert the position to the frame ordinal number using the provided frame rate. The first frame displayed at or after the
+// timestamp is selected, unless the last flag is set, in which case the last frame displayed at or before the timestamp is
+// selected.
+func (position framePosition) GetOrdinalNumber(fps float64, last bool) (int, error) {
+	if !position.timestamp {
+		return position.frame, nil
+	}
+
+	if fps <= 0 {
+		return 0, errors.New("detector: the frame rate of the video is unknown and the timestamp can not be converted to the frame")
+	}
+
+	frames := position.seconds * fps
+
+	// NOTE: The timestamps are usually multiples of the frame duration which are not represented exactly by the floats.
+	if math.Abs(frames-math.Round(frames)) < 1e-6 {
+		frames = math.Round(frames)
+	}
+
+	if last {
+		return int(math.Floor(frames)) + 1, nil
+	}
+
+	return int(math.Ceil(frames)) + 1, nil
+}
+
+// Structure representing the range of the video frames selected for the analysis. The frames are represented by the ordinal
+// numbers and every stride-th frame starting from the first frame of the range is analyzed. The last frame is equal to zero if
+// the range is not bounded.
+type framesRange struct {
+	first  int
+	last   int
+	stride int
+}
+
+// Create the frames range of the video based on the start, end and frame stride options. The timestamps are converted to the
+// frames ordinal numbers using the provided frame rate.
+func createFramesRange(options DetectorOptions, fps float64) (framesRange, error) {
+	frames := framesRange{
+		first:  1,
+		last:   0,
+		stride: int(options.FrameStride),
+	}
+
+	if len(options.AnalysisStart) != 0 {
+		position, err := parseFramePosition(options.AnalysisStart)
+		if err != nil {
+			return framesRange{}, fmt.Errorf("detector: invalid analysis start: %w", err)
+		}
+
+		if frames.first, err = position.GetOrdinalNumber(fps, false); err != nil {
+			return framesRange{}, fmt.Errorf("detector: invalid analysis start: %w", err)
+		}
+	}
+
+	if len(options.AnalysisEnd) != 0 {
+		position, err := parseFramePosition(options.AnalysisEnd)
+		if err != nil {
+			return framesRange{}, fmt.Errorf("detector: invalid analysis end: %w", err)
+		}
+
+		if frames.last, err = position.GetOrdinalNumber(fps, true); err != nil {
+			return framesRange{}, fmt.Errorf("detector: invalid analysis end: %w", err)
+		}
+
+		if frames.last < frames.first {
+			return framesRange{}, fmt.Errorf("detector: the analysis end frame %d precedes the analysis start frame %d", frames.last, frames.first)
+		}
+	}
+
+	return frames, nil
+}
+
+// Return a boolean value representing if the frame specified by the ordinal number is analyzed.
+func (frames framesRange) IsAnalyzed(frameNumber int) bool {
+	if frameNumber < frames.first || (frames.last != 0 && frameNumber > frames.last) {
+		return false
+	}
+
+	return (frameNumber-frames.first)%frames.stride == 0
+}
+
+// Return a boolean value representing if the image of the frame specified by the ordinal number is required, which is the case
+// for the analyzed frames and the frames directly preceding them, which are compared with the analyzed frames.
+func (frames framesRange) IsRequired(frameNumber int) bool {
+	return frames.IsAnalyzed(frameNumber) || frames.IsAnalyzed(frameNumber+1)
+}
+
+// Return a boolean value representing if the frame specified by the ordinal number is the last frame of the range.
+func (frames framesRange) IsLast(frameNumber int) bool {
+	return frames.last != 0 && frameNumber >= frames.last
+}
+
+// Return the ordinal number of the analyzed frame specified by the index.
+func (frames framesRange) GetOrdinalNumber(frameIndex int) int {
+	return frames.first + frameIndex*frames.stride
+}
+
+// Return the number of analyzed frames of the video with the given number of frames. Zero is returned if the number of frames
+// of the video is unknown.
+func (frames framesRange) Count(frameCount int) int {
+	if frameCount == 0 {
+		return 0
+	}
+
+	last := frameCount
+	if frames.last != 0 && frames.last < last {
+		last = frames.last
+	}
+
+	if last < frames.first {
+		return 0
+	}
+
+	return (last-frames.first)/frames.stride + 1
+}
+
+// Structure representing the sequential reader of the analyzed frames of the range from the frame source.
+type framesRangeReader struct {
+	frames      framesRange
+	video       source.FrameSource
+	frameNumber int
+}
+
+func createFramesRangeReader(frames framesRange, video source.FrameSource) *framesRangeReader {
+	return &framesRangeReader{
+		frames:      frames,
+		video:       video,
+		frameNumber: 0,
+	}
+}
+
+// Read the next analyzed frame into the frame buffer of the frame source. The frames that are not required are skipped and the
+// provided function is called when the frame directly preceding the analyzed frame, which is not analyzed itself, is stored in
+// the frame buffer. False is returned if there are no more analyzed frames or the reading failed.
+func (reader *framesRangeReader) Read(previous func() error) (bool, error) {
+	for !reader.frames.IsLast(reader.frameNumber) {
+		frameNumber := reader.frameNumber + 1
+
+		if !reader.frames.IsRequired(frameNumber) {
+			if !reader.video.Skip() {
+				return false, nil
+			}
+
+			reader.frameNumber = frameNumber
+			continue
+		}
+
+		if !reader.video.Read() {
+			return false, nil
+		}
+
+		reader.frameNumber = frameNumber
+		if reader.frames.IsAnalyzed(frameNumber) {
+			return true, nil
+		}
+
+		if err := previous(); err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// Return the ordinal number of the most recently read or skipped frame.
+func (reader *framesRangeReader) FrameNumber() int {
+	return reader.frameNumber
+}
@@ -0,0 +1,145 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramePositionShouldParseFrameNumbersAndTimestamps(t *testing.T) {
+	cases := []struct {
+		value    string
+		fps      float64
+		first    int
+		last     int
+		hasError bool
+	}{
+		{"1", 0, 1, 1, false},
+		{"1500", 0, 1500, 1500, false},
+		{"2s", 25, 51, 51, false},
+		{"1.2s", 25, 31, 31, false},
+		{"0.1s", 24, 4, 3, false},
+		{"01:30", 10, 901, 901, false},
+		{"01:00:00.5", 2, 7202, 7202, false},
+		{"00:00:00", 30, 1, 1, false},
+		{"0", 0, 0, 0, true},
+		{"-5", 0, 0, 0, true},
+		{"", 0, 0, 0, true},
+		{"1.5", 0, 0, 0, true},
+		{"abcs", 0, 0, 0, true},
+		{"01:60", 0, 0, 0, true},
+		{"01:61:00", 0, 0, 0, true},
+		{"1:2:3:4", 0, 0, 0, true},
+	}
+
+	for _, c := range cases {
+		position, err := parseFramePosition(c.value)
+		if c.hasError {
+			assert.NotNil(t, err, c.value)
+			continue
+		}
+
+		assert.Nil(t, err, c.value)
+
+		first, err := position.GetOrdinalNumber(c.fps, false)
+		assert.Nil(t, err, c.value)
+		assert.Equal(t, c.first, first, c.value)
+
+		last, err := position.GetOrdinalNumber(c.fps, true)
+		assert.Nil(t, err, c.value)
+		assert.Equal(t, c.last, last, c.value)
+	}
+}
+
+func TestFramePositionShouldRequireFrameRateForTimestamps(t *testing.T) {
+	position, err := parseFramePosition("10s")
+	assert.Nil(t, err)
+
+	_, err = position.GetOrdinalNumber(0, false)
+	assert.NotNil(t, err)
+}
+
+func TestFramesRangeShouldSelectAnalyzedFrames(t *testing.T) {
+	options := GetDefaultDetectorOptions()
+	options.AnalysisStart = "1s"
+	options.AnalysisEnd = "20"
+	options.FrameStride = 3
+
+	frames, err := createFramesRange(options, 10)
+	assert.Nil(t, err)
+
+	analyzed := make([]int, 0)
+	required := make([]int, 0)
+	for frameNumber := 1; frameNumber <= 30; frameNumber += 1 {
+		if frames.IsAnalyzed(frameNumber) {
+			analyzed = append(analyzed, frameNumber)
+		}
+
+		if frames.IsRequired(frameNumber) {
+			required = append(required, frameNumber)
+		}
+	}
+
+	assert.Equal(t, []int{11, 14, 17, 20}, analyzed)
+	assert.Equal(t, []int{10, 11, 13, 14, 16, 17, 19, 20}, required)
+	assert.Equal(t, 4, frames.Count(30))
+	assert.Equal(t, 3, frames.Count(19))
+	assert.Equal(t, 0, frames.Count(0))
+	assert.Equal(t, 17, frames.GetOrdinalNumber(2))
+
+	options.AnalysisEnd = "10"
+	_, err = createFramesRange(options, 10)
+	assert.NotNil(t, err)
+}
+
+func TestFramesRangeShouldSelectAllFramesByDefault(t *testing.T) {
+	frames, err := createFramesRange(GetDefaultDetectorOptions(), 0)
+	assert.Nil(t, err)
+
+	assert.True(t, frames.IsAnalyzed(1))
+	assert.True(t, frames.IsAnalyzed(1000))
+	assert.False(t, frames.IsLast(1000))
+	assert.Equal(t, 1000, frames.Count(1000))
+	assert.Equal(t, 1000, frames.GetOrdinalNumber(999))
+}
+
+func TestFramesRangeReaderShouldReadAnalyzedAndPrecedingFrames(t *testing.T) {
+	stream := make([]byte, 0, 12*4)
+	for frameNumber := 1; frameNumber <= 12; frameNumber += 1 {
+		stream = append(stream, byte(frameNumber), 0, 0, 255)
+	}
+
+	path := filepath.Join(t.TempDir(), "stream.rgba")
+	assert.Nil(t, os.WriteFile(path, stream, 0644))
+
+	video, err := source.CreateRawStreamSource(path, 1, 1, 0)
+	assert.Nil(t, err)
+	defer video.Close()
+
+	reader := createFramesRangeReader(framesRange{first: 3, last: 10, stride: 3}, video)
+
+	previous := make([]int, 0)
+	analyzed := make([]int, 0)
+	for {
+		ok, err := reader.Read(func() error {
+			previous = append(previous, int(video.FrameBuffer().Pix[0]))
+			return nil
+		})
+
+		assert.Nil(t, err)
+		if !ok {
+			break
+		}
+
+		assert.Equal(t, reader.FrameNumber(), int(video.FrameBuffer().Pix[0]))
+		analyzed = append(analyzed, reader.FrameNumber())
+	}
+
+	assert.Equal(t, []int{3, 6, 9}, analyzed)
+	assert.Equal(t, []int{2, 5, 8}, previous)
+	assert.Equal(t, 10, reader.FrameNumber())
+	assert.Nil(t, video.Err())
+}
@@ -0,0 +1,163 @@
+package detector
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+// Number of the next analyzed frames compared with the frame preceding the classified frame, which must be longer than the flash.
+const sceneCutPersistence = 3
+
+// Structure representing the classification of the scene cuts of the compiled videos. The scene change of the analyzed frame
+// is the lowest color histogram distance between the frame directly preceding it and the next analyzed frames, so the scene must
+// not return within the persistence frames. The lightning flash returns to the preceding scene, so the distance is low, while the
+// scene cut does not. The first frame of the video is not classified and the last frames are compared with the remaining frames.
+type sceneCutClassifier struct {
+	enabled         bool
+	pending         []sceneCutCandidate
+	last            []float64
+	lastFrameNumber int
+}
+
+// Structure representing the frame which scene change is not assigned yet, together with the histogram of the preceding frame.
+type sceneCutCandidate struct {
+	frame       *frame.Frame
+	preceding   []float64
+	comparisons int
+}
+
+// Create the scene cut classifier of the analyzed frames based on the scene cut detection options.
+func createSceneCutClassifier(options DetectorOptions) *sceneCutClassifier {
+	return &sceneCutClassifier{
+		enabled:         options.SceneCutDetection,
+		pending:         make([]sceneCutCandidate, 0, sceneCutPersistence),
+		last:            nil,
+		lastFrameNumber: 0,
+	}
+}
+
+// Update the scene change of the previously classified frames using the current frame image and store the histogram of the frame
+// preceding the current frame, so the scene change of the current frame can be assigned by the next calls. The frames must be
+// classified in the ascending order of the ordinal numbers.
+func (classifier *sceneCutClassifier) Classify(frameCurrent, framePrevious *image.RGBA, f *frame.Frame) error {
+	if !classifier.enabled {
+		return nil
+	}
+
+	current, err := utils.CreateColorHistogram(frameCurrent)
+	if err != nil {
+		return fmt.Errorf("detector: failed to create the current frame color histogram: %w", err)
+	}
+
+	pending := classifier.pending[:0]
+	for _, candidate := range classifier.pending {
+		distance, err := utils.HistogramDistance(candidate.preceding, current)
+		if err != nil {
+			return fmt.Errorf("detector: failed to calculate the scene change of the frame: %w", err)
+		}
+
+		if candidate.comparisons == 0 || distance < candidate.frame.SceneChange {
+			candidate.frame.SceneChange = distance
+		}
+
+		if candidate.comparisons += 1; candidate.comparisons < sceneCutPersistence {
+			pending = append(pending, candidate)
+		}
+	}
+
+	classifier.pending = pending
+
+	if f.OrdinalNumber != 1 {
+		preceding := classifier.last
+		if preceding == nil || classifier.lastFrameNumber != f.OrdinalNumber-1 {
+			if preceding, err = utils.CreateColorHistogram(framePrevious); err != nil {
+				return fmt.Errorf("detector: failed to create the previous frame color histogram: %w", err)
+			}
+		}
+
+		classifier.pending = append(classifier.pending, sceneCutCandidate{frame: f, preceding: preceding, comparisons: 0})
+	}
+
+	classifier.last, classifier.lastFrameNumber = current, f.OrdinalNumber
+	return nil
+}
+
+// Structure representing a single frame which met the detection requirements, but was classified as the scene cut and excluded
+// from the detections. The timestamp is the presentation timestamp of the frame in seconds and the time is the wall-clock time
+// of the frame, which is nil if unknown. The scene change is the color histogram distance between the frames surrounding the
+// cut frame and the score is the score of the frame decision.
+type SceneCut struct {
+	Frame       int        `json:"frame"`
+	Timestamp   float64    `json:"timestamp"`
+	Time        *time.Time `json:"time,omitempty"`
+	SceneChange float64    `json:"scene-change"`
+	Score       float64    `json:"score"`
+}
+
+// Create the scene cuts of the frames which decisions, stored by the frames indexes, are classified as the scene cuts.
+func CreateSceneCuts(frames []*frame.Frame, decisions []FrameDecision) []SceneCut {
+	cuts := make([]SceneCut, 0)
+	for frameIndex, decision := range decisions {
+		if !decision.SceneCut {
+			continue
+		}
+
+		cuts = append(cuts, SceneCut{
+			Frame:       frames[frameIndex].OrdinalNumber,
+			Timestamp:   frames[frameIndex].Timestamp,
+			Time:        frames[frameIndex].Time,
+			SceneChange: frames[frameIndex].SceneChange,
+			Score:       decision.Score,
+		})
+	}
+
+	return cuts
+}
+
+// Convert the scene cut to the string buffer format accepted by the CSV encoder.
+func (cut *SceneCut) ToBuffer() []string {
+	return []string{
+		strconv.Itoa(cut.Frame),
+		strconv.FormatFloat(cut.Timestamp, 'f', -1, 64),
+		formatEventTime(cut.Time),
+		strconv.FormatFloat(cut.SceneChange, 'f', -1, 64),
+		strconv.FormatFloat(cut.Score, 'f', -1, 64),
+	}
+}
+
+// Write the CSV format scene cuts report to the provided writer which can be a file reference.
+func ExportSceneCutsCsvReport(file io.Writer, cuts []SceneCut) error {
+	csvWriter := csv.NewWriter(file)
+	if err := csvWriter.Write([]string{"Frame", "Timestamp", "Time", "SceneChange", "Score"}); err != nil {
+		return fmt.Errorf("detector: failed to write the header to the scene cuts report file: %w", err)
+	}
+
+	for _, cut := range cuts {
+		if err := csvWriter.Write(cut.ToBuffer()); err != nil {
+			return fmt.Errorf("detector: failed to write the scene cut to the scene cuts report file: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	return nil
+}
+
+// Write the JSON format scene cuts report to the provided writer which can be a file reference.
+func ExportSceneCutsJsonReport(file io.Writer, cuts []SceneCut) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(cuts); err != nil {
+		return fmt.Errorf("detector: failed to encode the scene cuts to json report file: %w", err)
+	}
+
+	return nil
+}
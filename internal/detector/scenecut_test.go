@@ -0,0 +1,126 @@
+package detector
+
+import (
+	"bytes"
+	"encoding/csv"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneCutClassifierShouldDistinguishFlashFromCut(t *testing.T) {
+	scene := mockUniformImage(color.RGBA{40, 60, 90, 255})
+	flash := mockUniformImage(color.RGBA{255, 255, 255, 255})
+	cut := mockUniformImage(color.RGBA{160, 30, 20, 255})
+
+	images := []*image.RGBA{scene, scene, flash, scene, cut, cut, cut}
+	frames := mockFrames(len(images))
+
+	options := GetDefaultDetectorOptions()
+	options.SceneCutDetection = true
+	classifier := createSceneCutClassifier(options)
+
+	for index, img := range images {
+		previous := images[0]
+		if index != 0 {
+			previous = images[index-1]
+		}
+
+		assert.Nil(t, classifier.Classify(img, previous, frames[index]))
+	}
+
+	assert.Equal(t, []float64{0, 0, 0, 1, 1, 0, 0}, []float64{
+		frames[0].SceneChange,
+		frames[1].SceneChange,
+		frames[2].SceneChange,
+		frames[3].SceneChange,
+		frames[4].SceneChange,
+		frames[5].SceneChange,
+		frames[6].SceneChange,
+	})
+}
+
+func TestSceneCutClassifierShouldNotClassifyFlashLastingMultipleFrames(t *testing.T) {
+	scene := mockUniformImage(color.RGBA{40, 60, 90, 255})
+	flash := mockUniformImage(color.RGBA{255, 255, 255, 255})
+	cut := mockUniformImage(color.RGBA{160, 30, 20, 255})
+
+	images := []*image.RGBA{scene, scene, flash, flash, flash, scene, scene, cut, cut, cut, cut}
+	frames := mockFrames(len(images))
+
+	options := GetDefaultDetectorOptions()
+	options.SceneCutDetection = true
+	classifier := createSceneCutClassifier(options)
+
+	for index, img := range images {
+		previous := images[0]
+		if index != 0 {
+			previous = images[index-1]
+		}
+
+		assert.Nil(t, classifier.Classify(img, previous, frames[index]))
+	}
+
+	// NOTE: The first frame of the flash is followed by the flash frames, which are crossing the threshold, and by the scene.
+	assert.Less(t, frames[2].SceneChange, options.SceneCutThreshold)
+	assert.GreaterOrEqual(t, frames[7].SceneChange, options.SceneCutThreshold)
+
+	flashDecision := FrameDecision{Detected: true}
+	applySceneCut(options, frames[2], &flashDecision, false)
+	assert.True(t, flashDecision.Detected)
+	assert.False(t, flashDecision.SceneCut)
+
+	cutDecision := FrameDecision{Detected: true}
+	applySceneCut(options, frames[7], &cutDecision, false)
+	assert.False(t, cutDecision.Detected)
+	assert.True(t, cutDecision.SceneCut)
+}
+
+func TestSceneCutClassifierShouldNotClassifyWhenDisabled(t *testing.T) {
+	frames := mockFrames(3)
+	classifier := createSceneCutClassifier(GetDefaultDetectorOptions())
+
+	for index, c := range []color.RGBA{{0, 0, 0, 255}, {0, 0, 0, 255}, {255, 0, 0, 255}} {
+		assert.Nil(t, classifier.Classify(mockUniformImage(c), mockUniformImage(c), frames[index]))
+	}
+
+	assert.Equal(t, 0.0, frames[1].SceneChange)
+}
+
+func TestSceneCutsShouldBeCreatedFromDecisions(t *testing.T) {
+	frames := mockFrames(3)
+	frames[1].SceneChange = 0.9
+	frames[1].Timestamp = 0.04
+
+	decisions := []FrameDecision{
+		{Detected: true},
+		{Detected: false, SceneCut: true, Score: 0.25},
+		{Detected: false},
+	}
+
+	cuts := CreateSceneCuts(frames, decisions)
+	assert.Equal(t, []SceneCut{{Frame: 2, Timestamp: 0.04, SceneChange: 0.9, Score: 0.25}}, cuts)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ExportSceneCutsCsvReport(buffer, cuts))
+
+	records, err := csv.NewReader(buffer).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{
+		{"Frame", "Timestamp", "Time", "SceneChange", "Score"},
+		{"2", "0.04", "", "0.9", "0.25"},
+	}, records)
+}
+
+func mockUniformImage(c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y += 1 {
+		for x := 0; x < 8; x += 1 {
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
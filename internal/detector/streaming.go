@@ -0,0 +1,428 @@
+package detector
+
+import (
+	"fmt"
+	"image"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/source"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+// Structure representing a bounded window of consecutive analyzed frames accessed by the frames indexes.
+type framesWindow struct {
+	frames []*frame.Frame
+	offset int
+}
+
+func createFramesWindow() *framesWindow {
+	return &framesWindow{
+		frames: make([]*frame.Frame, 0),
+		offset: 0,
+	}
+}
+
+// Insert the next analyzed frame to the window.
+func (window *framesWindow) Append(f *frame.Frame) {
+	window.frames = append(window.frames, f)
+}
+
+// Return the frame specified by the index. Panic if the frame is no longer or not yet stored by the window.
+func (window *framesWindow) Get(index int) *frame.Frame {
+	if index < window.offset || index >= window.Count() {
+		panic(fmt.Sprintf("detector: the frame index %d is out of the frames window bounds", index))
+	}
+
+	return window.frames[index-window.offset]
+}
+
+// Return the total number of frames appended to the window including the frames that are no longer stored.
+func (window *framesWindow) Count() int {
+	return window.offset + len(window.frames)
+}
+
+// Remove the frames preceding the frame specified by the index from the window.
+func (window *framesWindow) Trim(index int) {
+	if index <= window.offset {
+		return
+	}
+
+	if index > window.Count() {
+		index = window.Count()
+	}
+
+	window.frames = window.frames[index-window.offset:]
+	window.offset = index
+}
+
+// Calculate the moving mean of the metric specified by the name for the frame specified by the position.
+func (window *framesWindow) GetMovingMean(name string, position, bias int) float64 {
+	values, valuesPosition := window.getMovingValues(name, position, bias)
+	return utils.MovingMean(values, valuesPosition, bias)
+}
+
+// Calculate the moving standard deviation of the metric specified by the name for the frame specified by the position.
+func (window *framesWindow) GetMovingStandardDeviation(name string, position, bias int) float64 {
+	values, valuesPosition := window.getMovingValues(name, position, bias)
+	return utils.MovingStandardDeviation(values, valuesPosition, bias)
+}
+
+// Helper function used to collect the metric values of the frame and its neighbours and the position of the frame among them.
+func (window *framesWindow) getMovingValues(name string, position, bias int) ([]float64, int) {
+	first := position - bias
+	if first < window.offset {
+		first = window.offset
+	}
+
+	last := position + bias
+	if last >= window.Count() {
+		last = window.Count() - 1
+	}
+
+	values := make([]float64, 0, last-first+1)
+	for index := first; index <= last; index += 1 {
+		values = append(values, window.Get(index).GetMetricValue(name))
+	}
+
+	return values, position - first
+}
+
+// Structure representing the detection performed on the frames as they are analyzed, delayed until the moving mean window is complete.
+type streamingDetection struct {
+	window     *framesWindow
+	detections DetectionBuffer
+	ordinals   []int
+	events     []LightningEvent
+	bias       int
+	maxGap     int
+	center     int
+	eventFirst int
+	eventLast  int
+	check      func(frameIndex int, f *frame.Frame, statistics movingStatistics) bool
+}
+
+// Create a new streaming detection instance using the provided detection buffer and the frame check function.
+func createStreamingDetection(movingMeanResolution, maxGap int, detections DetectionBuffer, check func(frameIndex int, f *frame.Frame, statistics movingStatistics) bool) *streamingDetection {
+	return &streamingDetection{
+		window:     createFramesWindow(),
+		detections: detections,
+		ordinals:   make([]int, 0),
+		events:     make([]LightningEvent, 0),
+		bias:       movingMeanResolution / 2,
+		maxGap:     maxGap,
+		center:     0,
+		eventFirst: -1,
+		eventLast:  -1,
+		check:      check,
+	}
+}
+
+// Insert the next analyzed frame and return the indexes of the detections resolved during the call.
+func (detection *streamingDetection) Append(f *frame.Frame) []int {
+	detection.window.Append(f)
+
+	resolved := make([]int, 0)
+	for detection.center+detection.bias < detection.window.Count() {
+		resolved = append(resolved, detection.checkNextFrame()...)
+	}
+
+	return resolved
+}
+
+// Perform the detection on the remaining frames and return the indexes of the detections resolved during the call.
+func (detection *streamingDetection) Close() []int {
+	resolved := make([]int, 0)
+	for detection.center < detection.window.Count() {
+		resolved = append(resolved, detection.checkNextFrame()...)
+	}
+
+	detection.detections.Close()
+	resolved = append(resolved, detection.handleResolvedDetections()...)
+
+	if detection.eventLast != -1 {
+		detection.closeEvent()
+	}
+
+	return resolved
+}
+
+// Return the ordinal numbers of all resolved detections.
+func (detection *streamingDetection) GetDetections() []int {
+	return detection.ordinals
+}
+
+// Return all closed lightning events.
+func (detection *streamingDetection) GetEvents() []LightningEvent {
+	return detection.events
+}
+
+func (detection *streamingDetection) checkNextFrame() []int {
+	frameIndex := detection.center
+	statistics := func(name string) (float64, float64) {
+		return detection.window.GetMovingMean(name, frameIndex, detection.bias), detection.window.GetMovingStandardDeviation(name, frameIndex, detection.bias)
+	}
+
+	detection.detections.Append(frameIndex, detection.check(frameIndex, detection.window.Get(frameIndex), statistics))
+	detection.center += 1
+
+	resolved := detection.handleResolvedDetections()
+
+	// NOTE: The detections preceding the detection buffer latency are final, so the event can be closed as soon as the
+	// lowest index which can still be resolved exceeds the maximal gap.
+	frontier := frameIndex - detection.detections.Latency() + 2
+	if detection.eventLast != -1 && frontier-detection.eventLast-1 > detection.maxGap {
+		detection.closeEvent()
+	}
+
+	trimIndex := utils.MinInt(detection.center-detection.bias, frontier)
+	if detection.eventFirst != -1 {
+		trimIndex = utils.MinInt(trimIndex, detection.eventFirst)
+	}
+
+	detection.window.Trim(trimIndex)
+	return resolved
+}
+
+// Helper function used to group the detections resolved since the previous call into the lightning events.
+func (detection *streamingDetection) handleResolvedDetections() []int {
+	resolved := detection.detections.ResolveLatest()
+	for _, index := range resolved {
+		detection.ordinals = append(detection.ordinals, detection.window.Get(index).OrdinalNumber)
+
+		if detection.eventLast != -1 && index-detection.eventLast-1 > detection.maxGap {
+			detection.closeEvent()
+		}
+
+		if detection.eventFirst == -1 {
+			detection.eventFirst = index
+		}
+
+		detection.eventLast = index
+	}
+
+	return resolved
+}
+
+func (detection *streamingDetection) closeEvent() {
+	offset := detection.window.offset
+	event := createLightningEvent(detection.window.frames, detection.eventFirst-offset, detection.eventLast-offset)
+
+	detection.events = append(detection.events, event)
+	detection.eventFirst, detection.eventLast = -1, -1
+}
+
+// Helper function used to perform the video analysis, detection and detected frames export in a single pass over the video.
+func (detector *detector) performStreamingDetection(inputVideoPath, outputDirectoryPath string) (DetectionResult, error) {
+	streamingDetectionTime := time.Now()
+	detector.renderer.LogDebug("Starting the streaming detection stage.")
+
+	video, err := detector.createFrameSource(inputVideoPath)
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: failed to open the frame source for the streaming detection stage: %w", err)
+	}
+
+	defer video.Close()
+
+	targetWidth := int(float64(video.Width()) * detector.options.FrameScalingFactor)
+	targetHeight := int(float64(video.Height()) * detector.options.FrameScalingFactor)
+
+	frameCurrentBuffer := video.FrameBuffer()
+
+	frameCurrent := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	framePrevious := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+
+	frameMask, err := detector.createFrameMask(targetWidth, targetHeight)
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: failed to create the frame mask for the streaming detection stage: %w", err)
+	}
+
+	metadata, err := detector.createVideoMetadata(inputVideoPath, video)
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: failed to create the video metadata for the streaming detection stage: %w", err)
+	}
+
+	frameCount := video.Frames()
+	framesClock := createFramesClock(metadata)
+	motionCompensator := createMotionCompensator(detector.options, targetWidth, targetHeight)
+
+	framesRange, err := createFramesRange(detector.options, video.FPS())
+	if err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: failed to create the frames range for the streaming detection stage: %w", err)
+	}
+
+	framesReader := createFramesRangeReader(framesRange, video)
+
+	detector.performThresholdsLogging()
+
+	hysteresis := &hysteresisState{}
+	detection := createStreamingDetection(int(detector.options.MovingMeanResolution), int(detector.options.EventFramesGap), createDetectionBuffer(detector.options), func(frameIndex int, f *frame.Frame, statistics movingStatistics) bool {
+		logPrefix := getFrameLogPrefix(f.OrdinalNumber, frameCount)
+		decision := detector.checkFrame(logPrefix, f, statistics, hysteresis.active)
+		return detector.applyHysteresis(logPrefix, hysteresis, &decision)
+	})
+
+	// NOTE: The frame images are stored until the detection of the frame is resolved, which happens at most after the moving
+	// mean bias and the detection buffer latency frames. The images of the file frame sources are copied from the original files.
+	fileVideo, isFileVideo := video.(source.FileFrameSource)
+
+	var frameImages []*image.RGBA = nil
+	if !detector.options.SkipFramesExport && !isFileVideo {
+		frameImages = make([]*image.RGBA, detection.bias+detection.detections.Latency())
+		for index := range frameImages {
+			frameImages[index] = image.NewRGBA(image.Rect(0, 0, video.Width(), video.Height()))
+		}
+	}
+
+	exportFrames := func(detections []int) error {
+		if !detector.options.QuietDetections {
+			for _, frameIndex := range detections {
+				detector.renderer.LogInfo("%s Detection confirmed.", getFrameLogPrefix(framesRange.GetOrdinalNumber(frameIndex), frameCount))
+			}
+		}
+
+		if detector.options.SkipFramesExport {
+			return nil
+		}
+
+		for _, frameIndex := range detections {
+			frameNumber := framesRange.GetOrdinalNumber(frameIndex)
+
+			if isFileVideo {
+				frameFilePath := fileVideo.GetFramePath(frameNumber - 1)
+				frameImageName := framesClock.GetFrameImageName(frameNumber, filepath.Ext(frameFilePath))
+				frameImagePath := path.Join(outputDirectoryPath, frameImageName)
+				if err := utils.CopyFile(frameFilePath, frameImagePath); err != nil {
+					return fmt.Errorf("detector: failed to copy the frame image file: %w", err)
+				}
+
+				detector.renderer.LogInfo("%s Frame image exported at: %s", getFrameLogPrefix(frameNumber, frameCount), frameImagePath)
+				continue
+			}
+
+			frameImageName := framesClock.GetFrameImageName(frameNumber, ".png")
+			frameImagePath := path.Join(outputDirectoryPath, frameImageName)
+			if err := utils.ExportImageAsPng(frameImagePath, frameImages[frameIndex%len(frameImages)]); err != nil {
+				return fmt.Errorf("detector: failed to export the frame image: %w", err)
+			}
+
+			detector.renderer.LogInfo("%s Frame image exported at: %s", getFrameLogPrefix(frameNumber, frameCount), frameImagePath)
+		}
+
+		return nil
+	}
+
+	progressBarStep, progressBarClose := detector.createProgress("Video streaming detection stage.", framesRange.Count(frameCount))
+
+	readPreviousFrame := func() error {
+		if err := utils.ScaleImage(frameCurrentBuffer, framePrevious, detector.options.FrameScalingFactor); err != nil {
+			return fmt.Errorf("detector: failed to scale the previous frame image on the streaming detection stage: %w", err)
+		}
+
+		if detector.options.Denoise {
+			if err := utils.BlurImage(framePrevious, framePrevious, 8); err != nil {
+				return fmt.Errorf("detector: failed to blur the previous frame image on the streaming detection stage: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	frameIndex := 0
+	for ; ; frameIndex += 1 {
+		ok, err := framesReader.Read(readPreviousFrame)
+		if err != nil {
+			return DetectionResult{}, err
+		}
+
+		if !ok {
+			break
+		}
+
+		frameNumber := framesReader.FrameNumber()
+
+		if err := utils.ScaleImage(frameCurrentBuffer, frameCurrent, detector.options.FrameScalingFactor); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: failed to scale the current frame image on the streaming detection stage: %w", err)
+		}
+
+		if detector.options.Denoise {
+			if err := utils.BlurImage(frameCurrent, frameCurrent, 8); err != nil {
+				return DetectionResult{}, fmt.Errorf("detector: failed to blur the current frame image on the streaming detection stage: %w", err)
+			}
+		}
+
+		if frameImages != nil {
+			copy(frameImages[frameIndex%len(frameImages)].Pix, frameCurrentBuffer.Pix)
+		}
+
+		framePreviousCompensated, err := motionCompensator.Compensate(frameCurrent, framePrevious, frameNumber)
+		if err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: failed to compensate the frame motion on the streaming detection stage: %w", err)
+		}
+
+		frame := frame.CreateNewTiledFrame(frameCurrent, framePreviousCompensated, frameNumber, frameMask, int(detector.options.TileColumns), int(detector.options.TileRows))
+		framesClock.Apply(frame)
+		motionCompensator.Apply(frame)
+		detector.renderer.LogDebug("%s Metrics: %v", getFrameLogPrefix(frameNumber, frameCount), frame.Metrics)
+
+		if err := exportFrames(detection.Append(frame)); err != nil {
+			return DetectionResult{}, fmt.Errorf("detector: failed to export the detected frames on the streaming detection stage: %w", err)
+		}
+
+		progressBarStep()
+		copy(framePrevious.Pix, frameCurrent.Pix)
+	}
+
+	if err := video.Err(); err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: failed to read the frame on the streaming detection stage: %w", err)
+	}
+
+	if err := exportFrames(detection.Close()); err != nil {
+		return DetectionResult{}, fmt.Errorf("detector: failed to export the detected frames on the streaming detection stage: %w", err)
+	}
+
+	progressBarClose()
+	detector.renderer.LogDebug("Streaming detection stage finished. Stage took: %s", time.Since(streamingDetectionTime))
+
+	detector.renderer.LogInfo("Detections: %d", len(detection.GetDetections()))
+
+	events := detection.GetEvents()
+	detector.renderer.LogInfo("Events: %d", len(events))
+	for index, event := range events {
+		detector.renderer.LogDebug("Event: [%d/%d]. Frames: %d-%d Peak: %d Peak time: %s", index+1, len(events), event.FirstFrame, event.LastFrame, event.PeakFrame, event.FormatPeakTime())
+	}
+
+	// NOTE: The number of frames of the live streams is unknown, so the number of read frames is used instead.
+	if metadata.Frames == 0 {
+		metadata.Frames = framesReader.FrameNumber()
+	}
+
+	if metadata.Duration == 0 && metadata.FPS > 0 {
+		metadata.Duration = float64(metadata.Frames) / metadata.FPS
+	}
+
+	result := DetectionResult{
+		Video:       metadata,
+		Detections:  detection.GetDetections(),
+		Events:      events,
+		FrameStride: framesRange.stride,
+	}
+
+	if frameIndex != 0 {
+		result.AnalyzedFirstFrame = framesRange.GetOrdinalNumber(0)
+		result.AnalyzedLastFrame = framesRange.GetOrdinalNumber(frameIndex - 1)
+	}
+
+	return result, nil
+}
+
+// Helper function used to format the log prefix of the frame, omitting the number of frames if it is unknown.
+func getFrameLogPrefix(frameNumber, frameCount int) string {
+	if frameCount == 0 {
+		return fmt.Sprintf("Frame: [%d].", frameNumber)
+	}
+
+	return fmt.Sprintf("Frame: [%d/%d].", frameNumber, frameCount)
+}
@@ -0,0 +1,122 @@
+package detector
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramesWindowShouldTrimAndAccessFramesByIndex(t *testing.T) {
+	window := createFramesWindow()
+	for _, f := range mockFrames(10) {
+		window.Append(f)
+	}
+
+	window.Trim(4)
+
+	assert.Equal(t, 10, window.Count())
+	assert.Equal(t, 5, window.Get(4).OrdinalNumber)
+	assert.Equal(t, 10, window.Get(9).OrdinalNumber)
+	assert.Panics(t, func() { window.Get(3) })
+
+	window.Trim(2)
+	assert.Panics(t, func() { window.Get(3) })
+}
+
+func TestStreamingDetectionShouldMatchWholeCollectionDetection(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	cases := []struct {
+		resolution   int
+		mode         string
+		windowLength int32
+		maxGap       int32
+		minRun       int32
+		maxRun       int32
+		sustain      float64
+	}{
+		{1, ThresholdDetectionMode, 4, 2, 1, 0, 0.0},
+		{4, ThresholdDetectionMode, 4, 2, 1, 0, 0.0},
+		{7, ThresholdDetectionMode, 4, 2, 1, 0, 0.0},
+		{50, ThresholdDetectionMode, 4, 2, 1, 0, 0.0},
+		{4, ZScoreDetectionMode, 4, 2, 1, 0, 0.0},
+		{7, ZScoreDetectionMode, 4, 2, 1, 0, 0.0},
+		{50, ZScoreDetectionMode, 4, 2, 1, 0, 0.0},
+		{7, ThresholdDetectionMode, 8, 5, 1, 0, 0.0},
+		{7, ThresholdDetectionMode, 6, 3, 3, 0, 0.0},
+		{7, ThresholdDetectionMode, 6, 3, 2, 6, 0.0},
+		{7, ThresholdDetectionMode, 4, 2, 1, 0, 0.02},
+		{7, ZScoreDetectionMode, 4, 2, 1, 0, 0.5},
+	}
+
+	for _, c := range cases {
+		resolution := c.resolution
+		frames := mockFrames(300)
+		collection := frame.CreateNewFramesCollection(len(frames))
+		for _, f := range frames {
+			for _, name := range frame.GetMetricsNames() {
+				f.Metrics[name] = random.Float64() * 0.3
+			}
+
+			if random.Intn(12) == 0 {
+				for _, name := range frame.GetMetricsNames() {
+					f.Metrics[name] += 0.6
+				}
+			}
+
+			assert.Nil(t, collection.Append(f))
+		}
+
+		options := GetDefaultDetectorOptions()
+		options.DetectionMode = c.mode
+		options.ZScoreSigma = 1.5
+		options.DetectionWindowLength = c.windowLength
+		options.DetectionMaxGap = c.maxGap
+		options.DetectionMinRunLength = c.minRun
+		options.DetectionMaxRunLength = c.maxRun
+		if c.sustain != 0.0 {
+			for _, name := range frame.GetMetricsNames() {
+				options.SetMetricSustainThreshold(name, c.sustain)
+			}
+		}
+		for _, name := range frame.GetMetricsNames() {
+			options.SetMetricThreshold(name, 0.1)
+		}
+
+		statistics := collection.CalculateStatistics(resolution)
+		detections := createDetectionBuffer(options)
+		hysteresis := &hysteresisState{}
+		for frameIndex, f := range frames {
+			decision := decideFrame(options, f, getFrameMovingStatistics(options, statistics, frameIndex))
+			detections.Append(frameIndex, hysteresis.Apply(&decision))
+		}
+
+		detections.Close()
+
+		expectedDetections := detections.Resolve()
+		expectedEvents := CreateLightningEvents(expectedDetections, frames, 2)
+
+		streamingHysteresis := &hysteresisState{}
+		streaming := createStreamingDetection(resolution, 2, createDetectionBuffer(options), func(_ int, f *frame.Frame, statistics movingStatistics) bool {
+			decision := decideFrame(options, f, statistics)
+			return streamingHysteresis.Apply(&decision)
+		})
+
+		actualDetections := make([]int, 0)
+		for _, f := range frames {
+			actualDetections = append(actualDetections, streaming.Append(f)...)
+		}
+
+		actualDetections = append(actualDetections, streaming.Close()...)
+
+		if resolution > 1 {
+			assert.NotEmpty(t, expectedDetections)
+		}
+
+		assert.Equal(t, expectedDetections, actualDetections)
+		assert.Len(t, streaming.GetDetections(), len(expectedDetections))
+		assert.Equal(t, expectedEvents, streaming.GetEvents())
+	}
+}
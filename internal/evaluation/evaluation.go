@@ -0,0 +1,205 @@
+package evaluation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+const (
+	TruePositiveOutcome  string = "TP"
+	FalsePositiveOutcome string = "FP"
+	FalseNegativeOutcome string = "FN"
+	TrueNegativeOutcome  string = "TN"
+)
+
+// Structure representing the accuracy scores of the detections compared with the ground-truth labels.
+type Scores struct {
+	TruePositives  int     `json:"true-positives"`
+	FalsePositives int     `json:"false-positives"`
+	FalseNegatives int     `json:"false-negatives"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+}
+
+// Structure representing the accuracy evaluation of a single detector run at the frame and the event level. The frames is the
+// number of the evaluated frames and the outcomes are the per-frame confusion classes of the evaluated frames, which ordinal
+// numbers are stored at the same positions.
+type Evaluation struct {
+	Frames         int      `json:"frames"`
+	FrameScores    Scores   `json:"frame-scores"`
+	EventScores    Scores   `json:"event-scores"`
+	Outcomes       []string `json:"-"`
+	OrdinalNumbers []int    `json:"-"`
+}
+
+// Compare the detections represented by the frames ordinal numbers and the detected events represented by the frames ranges
+// with the labelled frames ranges, assuming that all frames of the video with the given number of frames were analyzed. A
+// labelled range is matched at the event level if any detected event overlaps it and a detected event is a false positive if
+// it does not overlap any labelled range.
+func Evaluate(labels []FrameRange, detections []int, events []FrameRange, frames int) *Evaluation {
+	for _, ordinalNumber := range detections {
+		if ordinalNumber > frames {
+			frames = ordinalNumber
+		}
+	}
+
+	for _, label := range labels {
+		if label.Last > frames {
+			frames = label.Last
+		}
+	}
+
+	analyzed := make([]int, 0, frames)
+	for ordinalNumber := 1; ordinalNumber <= frames; ordinalNumber += 1 {
+		analyzed = append(analyzed, ordinalNumber)
+	}
+
+	return EvaluateAnalyzedFrames(labels, detections, events, analyzed)
+}
+
+// Compare the detections and the detected events with the labelled frames ranges only on the analyzed frames represented by the
+// ascending sorted ordinal numbers, so the frames outside the analyzed range or skipped by the frame stride are not scored. The
+// labelled ranges without any analyzed frame are not taken under account at the event level.
+func EvaluateAnalyzedFrames(labels []FrameRange, detections []int, events []FrameRange, analyzed []int) *Evaluation {
+	detected := make(map[int]bool, len(detections))
+	for _, ordinalNumber := range detections {
+		detected[ordinalNumber] = true
+	}
+
+	labelled := make(map[int]bool)
+	analyzedLabels := make([]FrameRange, 0, len(labels))
+	for _, label := range labels {
+		index := sort.SearchInts(analyzed, label.First)
+		if index == len(analyzed) || analyzed[index] > label.Last {
+			continue
+		}
+
+		for ; index < len(analyzed) && analyzed[index] <= label.Last; index += 1 {
+			labelled[analyzed[index]] = true
+		}
+
+		analyzedLabels = append(analyzedLabels, label)
+	}
+
+	labels = analyzedLabels
+
+	evaluation := &Evaluation{
+		Frames:         len(analyzed),
+		Outcomes:       make([]string, 0, len(analyzed)),
+		OrdinalNumbers: make([]int, 0, len(analyzed)),
+	}
+
+	frameScores := Scores{}
+	for _, ordinalNumber := range analyzed {
+		outcome := TrueNegativeOutcome
+		switch {
+		case detected[ordinalNumber] && labelled[ordinalNumber]:
+			outcome = TruePositiveOutcome
+			frameScores.TruePositives += 1
+		case detected[ordinalNumber]:
+			outcome = FalsePositiveOutcome
+			frameScores.FalsePositives += 1
+		case labelled[ordinalNumber]:
+			outcome = FalseNegativeOutcome
+			frameScores.FalseNegatives += 1
+		}
+
+		evaluation.Outcomes = append(evaluation.Outcomes, outcome)
+		evaluation.OrdinalNumbers = append(evaluation.OrdinalNumbers, ordinalNumber)
+	}
+
+	eventScores := Scores{}
+	for _, label := range labels {
+		matched := false
+		for _, event := range events {
+			if event.Overlaps(label) {
+				matched = true
+				break
+			}
+		}
+
+		if matched {
+			eventScores.TruePositives += 1
+		} else {
+			eventScores.FalseNegatives += 1
+		}
+	}
+
+	for _, event := range events {
+		matched := false
+		for _, label := range labels {
+			if event.Overlaps(label) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			eventScores.FalsePositives += 1
+		}
+	}
+
+	evaluation.FrameScores = calculateScores(frameScores)
+	evaluation.EventScores = calculateScores(eventScores)
+	return evaluation
+}
+
+// Helper function used to calculate the precision, recall and F1 score based on the outcomes counts. The precision is equal to
+// one if nothing was detected and the recall is equal to one if nothing was labelled.
+func calculateScores(scores Scores) Scores {
+	scores.Precision = 1
+	if scores.TruePositives+scores.FalsePositives != 0 {
+		scores.Precision = float64(scores.TruePositives) / float64(scores.TruePositives+scores.FalsePositives)
+	}
+
+	scores.Recall = 1
+	if scores.TruePositives+scores.FalseNegatives != 0 {
+		scores.Recall = float64(scores.TruePositives) / float64(scores.TruePositives+scores.FalseNegatives)
+	}
+
+	scores.F1 = 0
+	if scores.Precision+scores.Recall != 0 {
+		scores.F1 = 2 * scores.Precision * scores.Recall / (scores.Precision + scores.Recall)
+	}
+
+	return scores
+}
+
+// Write the CSV format per-frame confusion report to the provided writer which can be a file reference.
+func (evaluation *Evaluation) ExportConfusionCsvReport(file io.Writer) error {
+	csvWriter := csv.NewWriter(file)
+
+	if err := csvWriter.Write([]string{"Frame", "Labelled", "Detected", "Outcome"}); err != nil {
+		return fmt.Errorf("evaluation: failed to write the header to the confusion report file: %w", err)
+	}
+
+	for index, outcome := range evaluation.Outcomes {
+		labelled := outcome == TruePositiveOutcome || outcome == FalseNegativeOutcome
+		detected := outcome == TruePositiveOutcome || outcome == FalsePositiveOutcome
+
+		row := []string{strconv.Itoa(evaluation.OrdinalNumbers[index]), strconv.FormatBool(labelled), strconv.FormatBool(detected), outcome}
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("evaluation: failed to write the frame to the confusion report file: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	return nil
+}
+
+// Write the JSON format evaluation report to the provided writer which can be a file reference.
+func (evaluation *Evaluation) ExportJsonReport(file io.Writer) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "    ")
+
+	if err := encoder.Encode(evaluation); err != nil {
+		return fmt.Errorf("evaluation: failed to encode the evaluation to json report file: %w", err)
+	}
+
+	return nil
+}
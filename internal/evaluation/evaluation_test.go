@@ -0,0 +1,111 @@
+package evaluation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/detector"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateShouldCalculateFrameAndEventScores(t *testing.T) {
+	labels := []FrameRange{{First: 3, Last: 5}, {First: 10, Last: 10}}
+	detections := []int{4, 5, 6, 15}
+	events := []FrameRange{{First: 4, Last: 6}, {First: 15, Last: 15}}
+
+	evaluation := Evaluate(labels, detections, events, 20)
+
+	assert.Equal(t, 20, evaluation.Frames)
+	assert.Len(t, evaluation.Outcomes, 20)
+
+	assert.Equal(t, 2, evaluation.FrameScores.TruePositives)
+	assert.Equal(t, 2, evaluation.FrameScores.FalsePositives)
+	assert.Equal(t, 2, evaluation.FrameScores.FalseNegatives)
+	assert.InDelta(t, 0.5, evaluation.FrameScores.Precision, 1e-9)
+	assert.InDelta(t, 0.5, evaluation.FrameScores.Recall, 1e-9)
+	assert.InDelta(t, 0.5, evaluation.FrameScores.F1, 1e-9)
+
+	assert.Equal(t, 1, evaluation.EventScores.TruePositives)
+	assert.Equal(t, 1, evaluation.EventScores.FalsePositives)
+	assert.Equal(t, 1, evaluation.EventScores.FalseNegatives)
+}
+
+func TestEvaluateShouldScorePerfectlyWithoutLabelsAndDetections(t *testing.T) {
+	evaluation := Evaluate([]FrameRange{}, []int{}, []FrameRange{}, 10)
+
+	assert.Equal(t, 1.0, evaluation.FrameScores.Precision)
+	assert.Equal(t, 1.0, evaluation.FrameScores.Recall)
+	assert.Equal(t, 1.0, evaluation.FrameScores.F1)
+	assert.Equal(t, 1.0, evaluation.EventScores.F1)
+}
+
+func TestEvaluationShouldExportConfusionCsvReport(t *testing.T) {
+	evaluation := Evaluate([]FrameRange{{First: 2, Last: 3}}, []int{3, 4}, []FrameRange{{First: 3, Last: 4}}, 4)
+
+	buffer := &bytes.Buffer{}
+	err := evaluation.ExportConfusionCsvReport(buffer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"Frame,Labelled,Detected,Outcome",
+		"1,false,false,TN",
+		"2,true,false,FN",
+		"3,true,true,TP",
+		"4,false,true,FP",
+		"",
+	}, "\n"), buffer.String())
+}
+
+func TestEvaluateAnalyzedFramesShouldScoreOnlyAnalyzedFrames(t *testing.T) {
+	labels := []FrameRange{{First: 2, Last: 3}, {First: 6, Last: 8}, {First: 20, Last: 25}}
+	detections := []int{7, 10}
+	events := []FrameRange{{First: 7, Last: 7}, {First: 10, Last: 10}}
+
+	// NOTE: Every third frame of the frames range from 4 to 16 is analyzed.
+	evaluation := EvaluateAnalyzedFrames(labels, detections, events, []int{4, 7, 10, 13, 16})
+
+	assert.Equal(t, 5, evaluation.Frames)
+	assert.Equal(t, []int{4, 7, 10, 13, 16}, evaluation.OrdinalNumbers)
+	assert.Equal(t, []string{TrueNegativeOutcome, TruePositiveOutcome, FalsePositiveOutcome, TrueNegativeOutcome, TrueNegativeOutcome}, evaluation.Outcomes)
+
+	assert.Equal(t, 1, evaluation.FrameScores.TruePositives)
+	assert.Equal(t, 1, evaluation.FrameScores.FalsePositives)
+	assert.Equal(t, 0, evaluation.FrameScores.FalseNegatives)
+
+	assert.Equal(t, 1, evaluation.EventScores.TruePositives)
+	assert.Equal(t, 1, evaluation.EventScores.FalsePositives)
+	assert.Equal(t, 0, evaluation.EventScores.FalseNegatives)
+}
+
+func TestEvaluateShouldMatchPositiveSampleLabels(t *testing.T) {
+	labels, err := LoadLabels("../../resources/samples/sample_yes_sequence.labels")
+	assert.Nil(t, err)
+
+	options := detector.GetDefaultDetectorOptions()
+	options.AutoThresholds = true
+	options.SkipFramesExport = true
+
+	detectorInstance, err := detector.CreateDetector(render.CreateRenderer(false), options)
+	assert.Nil(t, err)
+
+	result, err := detectorInstance.Run("../../resources/samples/sample_yes_sequence", t.TempDir())
+	assert.Nil(t, err)
+
+	labelsRanges, err := labels.GetFrameRanges(result.Video.FPS, result.Video.StartTimestamp)
+	assert.Nil(t, err)
+	assert.Equal(t, []FrameRange{{First: 14, Last: 15}}, labelsRanges)
+
+	eventsRanges := make([]FrameRange, 0, len(result.Events))
+	for _, event := range result.Events {
+		eventsRanges = append(eventsRanges, FrameRange{First: event.FirstFrame, Last: event.LastFrame})
+	}
+
+	evaluation := EvaluateAnalyzedFrames(labelsRanges, result.Detections, eventsRanges, result.GetAnalyzedFrames())
+
+	assert.Equal(t, 30, evaluation.Frames)
+	assert.Equal(t, 0, evaluation.FrameScores.FalsePositives)
+	assert.Greater(t, evaluation.FrameScores.TruePositives, 0)
+	assert.InDelta(t, 1.0, evaluation.EventScores.F1, 1e-9)
+}
@@ -0,0 +1,250 @@
+package evaluation
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Structure representing an inclusive range of frames represented by the frames ordinal numbers.
+type FrameRange struct {
+	First int `json:"first"`
+	Last  int `json:"last"`
+}
+
+// Return a boolean value representing if the frame specified by the ordinal number is in the range.
+func (r FrameRange) Contains(ordinalNumber int) bool {
+	return ordinalNumber >= r.First && ordinalNumber <= r.Last
+}
+
+// Return a boolean value representing if the ranges have at least one common frame.
+func (r FrameRange) Overlaps(other FrameRange) bool {
+	return r.First <= other.Last && other.First <= r.Last
+}
+
+// Structure representing a single ground-truth label of a lightning strike. The label is specified either by the frames ordinal
+// numbers or by the timestamps in seconds, which are converted to the frames using the video frame rate.
+type Label struct {
+	FirstFrame   int
+	LastFrame    int
+	FirstSeconds float64
+	LastSeconds  float64
+	IsTimestamp  bool
+}
+
+// Structure representing the ground-truth labels of the lightning strikes of a single video.
+type Labels struct {
+	Labels []Label
+}
+
+// Read and parse the labels from the file specified by the path.
+func LoadLabels(path string) (*Labels, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("evaluation: failed to open the labels file: %w", err)
+	}
+
+	defer file.Close()
+
+	return ImportLabels(file)
+}
+
+// Parse the labels from the provided reader which can be a file reference. Each non-empty line which is not a comment starting
+// with the # character represents a single label. The label is a frame ordinal number (e.g. "120"), a range of frames ordinal
+// numbers (e.g. "120-135"), a timestamp or a range of timestamps. The timestamps are specified as seconds with the "s" suffix
+// (e.g. "4.5s-5s") or in the "hh:mm:ss.fff" or "mm:ss.fff" format (e.g. "00:01:04.5-00:01:05").
+func ImportLabels(file io.Reader) (*Labels, error) {
+	labels := &Labels{
+		Labels: make([]Label, 0),
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+
+		line := scanner.Text()
+		if index := strings.Index(line, "#"); index != -1 {
+			line = line[:index]
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		label, err := parseLabel(line)
+		if err != nil {
+			return nil, fmt.Errorf("evaluation: invalid label at line %d: %w", lineNumber, err)
+		}
+
+		labels.Labels = append(labels.Labels, label)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("evaluation: failed to read the labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// Convert the labels to the ascending sorted and merged frames ranges. The frame rate is required only for the timestamp labels.
+// The timestamps are the presentation timestamps of the video, as in the reports, and the start timestamp is the presentation
+// timestamp of the first frame. A timestamp is assigned to the frame which is displayed at the given time and the timestamp
+// labels ending before the start of the video are ignored.
+func (labels *Labels) GetFrameRanges(fps, startTimestamp float64) ([]FrameRange, error) {
+	ranges := make([]FrameRange, 0, len(labels.Labels))
+	for _, label := range labels.Labels {
+		if !label.IsTimestamp {
+			ranges = append(ranges, FrameRange{First: label.FirstFrame, Last: label.LastFrame})
+			continue
+		}
+
+		if fps <= 0 {
+			return nil, errors.New("evaluation: the timestamp labels require a known video frame rate")
+		}
+
+		first := getTimestampFrame(label.FirstSeconds, fps, startTimestamp)
+		last := getTimestampFrame(label.LastSeconds, fps, startTimestamp)
+		if last < 1 {
+			continue
+		}
+
+		if first < 1 {
+			first = 1
+		}
+
+		ranges = append(ranges, FrameRange{First: first, Last: last})
+	}
+
+	return mergeFrameRanges(ranges), nil
+}
+
+// Helper function used to convert the presentation timestamp in seconds to the ordinal number of the frame displayed at the
+// timestamp, which is lower than one if the timestamp precedes the start timestamp.
+func getTimestampFrame(seconds, fps, startTimestamp float64) int {
+	frames := (seconds - startTimestamp) * fps
+
+	// NOTE: The timestamps are usually multiples of the frame duration which are not represented exactly by the floats.
+	if math.Abs(frames-math.Round(frames)) < 1e-6 {
+		frames = math.Round(frames)
+	}
+
+	return int(math.Floor(frames)) + 1
+}
+
+// Helper function used to sort the ranges and merge the overlapping or adjacent ones.
+func mergeFrameRanges(ranges []FrameRange) []FrameRange {
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].First < ranges[j].First
+	})
+
+	merged := make([]FrameRange, 0, len(ranges))
+	for _, r := range ranges {
+		if len(merged) != 0 && r.First <= merged[len(merged)-1].Last+1 {
+			if r.Last > merged[len(merged)-1].Last {
+				merged[len(merged)-1].Last = r.Last
+			}
+
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+func parseLabel(value string) (Label, error) {
+	first, last := value, value
+	if index := strings.Index(value[1:], "-"); index != -1 {
+		first, last = value[:index+1], value[index+2:]
+	}
+
+	first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+	if isTimestamp(first) || isTimestamp(last) {
+		firstSeconds, err := parseTimestamp(first)
+		if err != nil {
+			return Label{}, err
+		}
+
+		lastSeconds, err := parseTimestamp(last)
+		if err != nil {
+			return Label{}, err
+		}
+
+		if lastSeconds < firstSeconds {
+			return Label{}, fmt.Errorf("the label %q end precedes the start", value)
+		}
+
+		return Label{FirstSeconds: firstSeconds, LastSeconds: lastSeconds, IsTimestamp: true}, nil
+	}
+
+	firstFrame, err := strconv.Atoi(first)
+	if err != nil || firstFrame < 1 {
+		return Label{}, fmt.Errorf("the label %q frame must be a positive integer", value)
+	}
+
+	lastFrame, err := strconv.Atoi(last)
+	if err != nil || lastFrame < 1 {
+		return Label{}, fmt.Errorf("the label %q frame must be a positive integer", value)
+	}
+
+	if lastFrame < firstFrame {
+		return Label{}, fmt.Errorf("the label %q end precedes the start", value)
+	}
+
+	return Label{FirstFrame: firstFrame, LastFrame: lastFrame, IsTimestamp: false}, nil
+}
+
+func isTimestamp(value string) bool {
+	return strings.HasSuffix(value, "s") || strings.Contains(value, ":")
+}
+
+// Helper function used to parse the timestamp in the "12.5s", "mm:ss.fff" or "hh:mm:ss.fff" format into seconds.
+func parseTimestamp(value string) (float64, error) {
+	if strings.HasSuffix(value, "s") {
+		seconds, err := strconv.ParseFloat(strings.TrimSuffix(value, "s"), 64)
+		if err != nil || seconds < 0 {
+			return 0, fmt.Errorf("the timestamp %q is invalid", value)
+		}
+
+		return seconds, nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("the timestamp %q is invalid", value)
+	}
+
+	seconds := 0.0
+	for index, part := range parts {
+		var (
+			partValue float64
+			err       error
+		)
+
+		if index == len(parts)-1 {
+			partValue, err = strconv.ParseFloat(part, 64)
+		} else {
+			var integer int
+			integer, err = strconv.Atoi(part)
+			partValue = float64(integer)
+		}
+
+		if err != nil || partValue < 0 {
+			return 0, fmt.Errorf("the timestamp %q is invalid", value)
+		}
+
+		seconds = seconds*60 + partValue
+	}
+
+	return seconds, nil
+}
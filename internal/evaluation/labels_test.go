@@ -0,0 +1,70 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldImportFrameAndTimestampLabels(t *testing.T) {
+	content := `
+# Ground-truth labels
+120-135
+200 # single frame
+4.5s-5s
+00:01:00-00:01:00.5
+`
+
+	labels, err := ImportLabels(strings.NewReader(content))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Label{
+		{FirstFrame: 120, LastFrame: 135},
+		{FirstFrame: 200, LastFrame: 200},
+		{FirstSeconds: 4.5, LastSeconds: 5, IsTimestamp: true},
+		{FirstSeconds: 60, LastSeconds: 60.5, IsTimestamp: true},
+	}, labels.Labels)
+}
+
+func TestShouldNotImportInvalidLabels(t *testing.T) {
+	cases := []string{"abc", "0", "10-5", "-5", "5s-4s", "1:2:3:4", "1:x", "1-2-3"}
+
+	for _, c := range cases {
+		labels, err := ImportLabels(strings.NewReader(c))
+
+		assert.Nil(t, labels)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestShouldConvertLabelsToMergedFrameRanges(t *testing.T) {
+	labels, err := ImportLabels(strings.NewReader("30-40\n1s-1.1s\n10-12\n13\n"))
+	assert.Nil(t, err)
+
+	ranges, err := labels.GetFrameRanges(30, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []FrameRange{{First: 10, Last: 13}, {First: 30, Last: 40}}, ranges)
+}
+
+func TestShouldNotConvertTimestampLabelsWithoutFrameRate(t *testing.T) {
+	labels, err := ImportLabels(strings.NewReader("1s-2s"))
+	assert.Nil(t, err)
+
+	ranges, err := labels.GetFrameRanges(0, 0)
+
+	assert.Nil(t, ranges)
+	assert.NotNil(t, err)
+}
+
+func TestShouldConvertTimestampLabelsRelativeToStartTimestamp(t *testing.T) {
+	labels, err := ImportLabels(strings.NewReader("1.5s-1.6s\n0.1s-0.6s\n0.1s-0.2s\n"))
+	assert.Nil(t, err)
+
+	// NOTE: The first frame of the video is displayed at 0.5s, so the label ending at 0.2s precedes the video.
+	ranges, err := labels.GetFrameRanges(25, 0.5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []FrameRange{{First: 1, Last: 3}, {First: 26, Last: 28}}, ranges)
+}
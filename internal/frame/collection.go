@@ -6,15 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 )
 
 // Structure representing the collection of video frames.
 type FramesCollection struct {
-	Frames                     map[int]*Frame
-	cachedStatisticsValue      *FramesStatistics
-	cachedStatisticsResolution int
-	mu                         sync.RWMutex
+	Frames                      map[int]*Frame
+	cachedStatisticsValue       *FramesStatistics
+	cachedStatisticsResolution  int
+	cachedStatisticsPercentiles []float64
+	mu                          sync.RWMutex
 }
 
 // Create a new frames collection with a given capacity of frames.
@@ -43,6 +45,7 @@ func (frames *FramesCollection) Append(frame *Frame) error {
 	frames.Frames[frame.OrdinalNumber] = frame
 	frames.cachedStatisticsValue = nil
 	frames.cachedStatisticsResolution = 0
+	frames.cachedStatisticsPercentiles = nil
 	return nil
 }
 
@@ -59,7 +62,6 @@ func (frames *FramesCollection) Get(frameNumber int) (*Frame, error) {
 }
 
 // Get all frames sorted by the frame ordinal number.
-// TODO: Add tests
 func (frames *FramesCollection) GetAll() []*Frame {
 	frames.mu.RLock()
 	defer frames.mu.RUnlock()
@@ -67,35 +69,53 @@ func (frames *FramesCollection) GetAll() []*Frame {
 	return frames.mapFramesToSlice()
 }
 
-// Get all frames sorted by the frame ordinal nubmer. This function does not lock and should only be used intrnaly by the FramesCollection
+// Get all frames sorted by the frame ordinal nubmer. This function does not lock and should only be used intrnaly by the FramesCollection.
+// The ordinal numbers are not required to be consecutive, so the collection can store only a range or every n-th frame of the video.
 func (frames *FramesCollection) mapFramesToSlice() []*Frame {
-	values := make([]*Frame, len(frames.Frames))
-	for index := 0; index < len(frames.Frames); index += 1 {
-		frameNumber := index + 1
-		frame, ok := frames.Frames[frameNumber]
-		if !ok {
-			panic("frame: missing frame spotted during frames iteration")
-		}
+	frameNumbers := make([]int, 0, len(frames.Frames))
+	for frameNumber := range frames.Frames {
+		frameNumbers = append(frameNumbers, frameNumber)
+	}
+
+	sort.Ints(frameNumbers)
 
-		values[index] = frame
+	values := make([]*Frame, 0, len(frameNumbers))
+	for _, frameNumber := range frameNumbers {
+		values = append(values, frames.Frames[frameNumber])
 	}
 
 	return values
 }
 
-// Calculate the descriptive statistics values for the given frames collection.
-func (frames *FramesCollection) CalculateStatistics(movingMeanResolution int) FramesStatistics {
+// Calculate the descriptive statistics values for the given frames collection. The percentiles values must be between zero
+// and one hundred.
+func (frames *FramesCollection) CalculateStatistics(movingMeanResolution int, percentiles ...float64) FramesStatistics {
 	frames.mu.RLock()
 	defer frames.mu.RUnlock()
 
-	if frames.cachedStatisticsValue == nil || frames.cachedStatisticsResolution != movingMeanResolution {
-		frames.cachedStatisticsValue = CreateNewFramesStatistics(frames.mapFramesToSlice(), movingMeanResolution)
+	if frames.cachedStatisticsValue == nil || frames.cachedStatisticsResolution != movingMeanResolution || !equalPercentiles(frames.cachedStatisticsPercentiles, percentiles) {
+		frames.cachedStatisticsValue = CreateNewFramesStatistics(frames.mapFramesToSlice(), movingMeanResolution, percentiles...)
 		frames.cachedStatisticsResolution = movingMeanResolution
+		frames.cachedStatisticsPercentiles = append([]float64{}, percentiles...)
 	}
 
 	return *frames.cachedStatisticsValue
 }
 
+func equalPercentiles(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Write the JSON format frames report to the provided writer which can be a file reference.
 func (frames *FramesCollection) ExportJsonReport(file io.Writer) error {
 	framesSlice := frames.GetAll()
@@ -114,8 +134,13 @@ func (frames *FramesCollection) ExportJsonReport(file io.Writer) error {
 func (frames *FramesCollection) ExportCsvReport(file io.Writer) error {
 	framesSlice := frames.GetAll()
 
+	names := GetMetricsNames()
+	if len(framesSlice) != 0 {
+		names = framesSlice[0].GetMetricsNames()
+	}
+
 	csvWriter := csv.NewWriter(file)
-	if err := csvWriter.Write([]string{"Frame", "Brightness", "ColorDifference", "BinaryThresholdDifference"}); err != nil {
+	if err := csvWriter.Write(append([]string{"Frame", "Timestamp", "Time", "ShiftX", "ShiftY", "SceneChange"}, names...)); err != nil {
 		return fmt.Errorf("frame: failed to write the header to the frames report file: %w", err)
 	}
 
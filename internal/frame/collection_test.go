@@ -66,6 +66,20 @@ func TestFramesCollectionShouldNotGetNotExistingFrame(t *testing.T) {
 	assert.Nil(t, frame)
 }
 
+func TestFramesCollectionShouldGetAllFramesSortedByNotConsecutiveOrdinalNumbers(t *testing.T) {
+	collection := CreateNewFramesCollection(3)
+	for _, ordinalNumber := range []int{25, 5, 15} {
+		assert.Nil(t, collection.Append(CreateNewFrame(mockImage(color.White), mockImage(color.White), ordinalNumber)))
+	}
+
+	frames := collection.GetAll()
+
+	assert.Len(t, frames, 3)
+	assert.Equal(t, 5, frames[0].OrdinalNumber)
+	assert.Equal(t, 15, frames[1].OrdinalNumber)
+	assert.Equal(t, 25, frames[2].OrdinalNumber)
+}
+
 func TestFramesCollectionShouldCalculateStatistics(t *testing.T) {
 	frame1 := CreateNewFrame(mockImage(color.White), mockImage(color.Black), 1)
 	frame2 := CreateNewFrame(mockImage(color.Black), mockImage(color.White), 2)
@@ -79,28 +93,28 @@ func TestFramesCollectionShouldCalculateStatistics(t *testing.T) {
 
 	statistics := collection.CalculateStatistics(50)
 
-	assert.Equal(t, statistics.BrightnessMean, 0.5)
-	assert.Equal(t, statistics.BrightnessStandardDeviation, 0.5)
-	assert.Equal(t, statistics.BrightnessMax, 1.0)
-	assert.Equal(t, statistics.ColorDifferenceMean, 0.5)
-	assert.Equal(t, statistics.ColorDifferenceStandardDeviation, 0.5)
-	assert.Equal(t, statistics.ColorDifferenceMax, 1.0)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMean, 0.5)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceStandardDeviation, 0.5)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMax, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).Max, 1.0)
 
 	cachedStatistics := collection.CalculateStatistics(50)
 
 	assert.Equal(t, cachedStatistics, statistics)
-	assert.Equal(t, statistics.BrightnessMean, 0.5)
-	assert.Equal(t, statistics.BrightnessStandardDeviation, 0.5)
-	assert.Equal(t, statistics.BrightnessMax, 1.0)
-	assert.Equal(t, statistics.ColorDifferenceMean, 0.5)
-	assert.Equal(t, statistics.ColorDifferenceStandardDeviation, 0.5)
-	assert.Equal(t, statistics.ColorDifferenceMax, 1.0)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMean, 0.5)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceStandardDeviation, 0.5)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMax, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).Max, 1.0)
 }
 
 func TestFramesCollectionShouldExportJsonReport(t *testing.T) {
@@ -2,114 +2,108 @@ package frame
 
 import (
 	"image"
-	"image/color"
 	"strconv"
 	"sync"
-
-	"github.com/Krzysztofz01/pimit"
-	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
-	"go.uber.org/atomic"
-)
-
-const (
-	// TODO: Implement different threshold for day/night. The brightness value can be used as the determinant.
-	BinaryThresholdParam float64 = 0.784313725
+	"time"
 )
 
-// Strucutre representing a single video frame and its calculated parameters.
-// TODO: When it coms to BinaryThreshold we need to test which approach gives better results.
-// Currently we are comparing the BT of the previous and current frame and than calcualte the white_pixels / all_pixels
-// Alternatively we can just count the occurance of white pixels and return the non-normalized result
+// Strucutre representing a single video frame and its calculated metrics values stored by the metrics names. The timestamp is
+// the presentation timestamp of the frame in seconds and the time is the wall-clock time of the frame, which is nil if unknown.
+// The shift is the estimated translation of the frame content relative to the previous frame in the original frame pixels, which
+// is zero if the motion is not compensated. The scene change is the color histogram distance between the frames surrounding the
+// frame, which is zero if the scene cuts are not classified. The tiles is the number of the tiles of the frame grid, which metrics are stored by
+// the tiles metrics names, and is zero if the frame is not split into tiles. The regions are the changed regions of the frame
+// found by the strike localization, which are nil if not localized.
 type Frame struct {
-	OrdinalNumber             int     `json:"ordinal-number"`
-	ColorDifference           float64 `json:"color-difference"`
-	BinaryThresholdDifference float64 `json:"binary-threshold-difference"`
-	Brightness                float64 `json:"brightness"`
+	OrdinalNumber int                `json:"ordinal-number"`
+	Timestamp     float64            `json:"timestamp"`
+	Time          *time.Time         `json:"time,omitempty"`
+	ShiftX        float64            `json:"shift-x"`
+	ShiftY        float64            `json:"shift-y"`
+	SceneChange   float64            `json:"scene-change"`
+	Metrics       map[string]float64 `json:"metrics"`
+	Tiles         int                `json:"tiles,omitempty"`
+	Regions       []ChangedRegion    `json:"regions,omitempty"`
 }
 
 // Create a new frame instance by providing the current and previous frame images and the ordinal number of the frame.
 func CreateNewFrame(currentFrame, previousFrame image.Image, ordinalNumber int) *Frame {
-	frame := &Frame{
-		OrdinalNumber: ordinalNumber,
-	}
-
-	wg := sync.WaitGroup{}
-	wg.Add(3)
+	return CreateNewMaskedFrame(currentFrame, previousFrame, ordinalNumber, nil)
+}
 
-	go func() {
-		defer wg.Done()
+// Create a new frame instance by providing the current and previous frame images, the ordinal number of the frame and
+// the mask of pixels which should be taken under account. A nil mask includes all pixels of the frame.
+func CreateNewMaskedFrame(currentFrame, previousFrame image.Image, ordinalNumber int, mask *FrameMask) *Frame {
+	if ordinalNumber == 1 {
+		previousFrame = nil
+	}
 
-		frame.Brightness = calculateFrameBrightness(currentFrame)
-	}()
+	return &Frame{
+		OrdinalNumber: ordinalNumber,
+		Metrics:       computeMetrics(currentFrame, previousFrame, mask),
+	}
+}
 
-	go func() {
-		defer wg.Done()
-		if ordinalNumber == 1 {
-			frame.ColorDifference = 0.0
-			return
-		}
+// Helper function used to calculate the values of all metrics concurrently and store them by the metrics names.
+func computeMetrics(currentFrame, previousFrame image.Image, mask *FrameMask) map[string]float64 {
+	metrics := GetMetrics()
+	values := make([]float64, len(metrics))
 
-		frame.ColorDifference = calculateFramesColorDifference(currentFrame, previousFrame)
-	}()
+	wg := sync.WaitGroup{}
+	wg.Add(len(metrics))
 
-	go func() {
-		defer wg.Done()
-		if ordinalNumber == 1 {
-			frame.BinaryThresholdDifference = 0.0
-			return
-		}
+	for index, metric := range metrics {
+		go func(index int, metric Metric) {
+			defer wg.Done()
 
-		frame.BinaryThresholdDifference = calculateFramesBinaryThresholdDifference(currentFrame, previousFrame)
-	}()
+			values[index] = metric.Compute(currentFrame, previousFrame, mask)
+		}(index, metric)
+	}
 
 	wg.Wait()
-	return frame
-}
 
-func calculateFrameBrightness(currentFrame image.Image) float64 {
-	brightness := atomic.NewFloat64(0.0)
-	pimit.ParallelRead(currentFrame, func(_, _ int, c color.Color) {
-		brightness.Add(utils.GetColorBrightness(c))
-	})
+	metricsValues := make(map[string]float64, len(metrics))
+	for index, metric := range metrics {
+		metricsValues[metric.Name()] = values[index]
+	}
 
-	frameSize := currentFrame.Bounds().Dx() * currentFrame.Bounds().Dy()
-	return brightness.Load() / float64(frameSize)
+	return metricsValues
 }
 
-func calculateFramesColorDifference(currentFrame, previousFrame image.Image) float64 {
-	difference := atomic.NewFloat64(0.0)
-	pimit.ParallelRead(currentFrame, func(x, y int, currentFrameColor color.Color) {
-		previousFrameColor := previousFrame.At(x, y)
-
-		difference.Add(utils.GetColorDifference(currentFrameColor, previousFrameColor))
-	})
-
-	frameSize := currentFrame.Bounds().Dx() * currentFrame.Bounds().Dy()
-	return difference.Load() / float64(frameSize)
+// Return the value of the metric specified by the name. Zero is returned if the metric value is not present.
+func (frame *Frame) GetMetricValue(name string) float64 {
+	return frame.Metrics[name]
 }
 
-func calculateFramesBinaryThresholdDifference(currentFrame, previousFrame image.Image) float64 {
-	difference := atomic.NewInt32(0)
-	pimit.ParallelRead(currentFrame, func(x, y int, currentFrameColor color.Color) {
-		thresholdCurrent := utils.BinaryThreshold(currentFrameColor, BinaryThresholdParam)
-		thresholdPrevious := utils.BinaryThreshold(previousFrame.At(x, y), BinaryThresholdParam)
+// Return the wall-clock time of the frame in the RFC 3339 format. An empty string is returned if the time is unknown.
+func (frame *Frame) FormatTime() string {
+	if frame.Time == nil {
+		return ""
+	}
 
-		if thresholdCurrent != thresholdPrevious {
-			difference.Add(1)
-		}
-	})
+	return frame.Time.Format(time.RFC3339Nano)
+}
 
-	frameSize := currentFrame.Bounds().Dx() * currentFrame.Bounds().Dy()
-	return float64(difference.Load()) / float64(frameSize)
+// Return the ordered collection of the names of the metrics of the frame including the metrics of the tiles of the frame.
+func (frame *Frame) GetMetricsNames() []string {
+	return GetTiledMetricsNames(frame.Tiles)
 }
 
-// Convert the frame string buffer format accepted by the CSV encoder.
+// Convert the frame string buffer format accepted by the CSV encoder. The metrics values are ordered as the frame metrics
+// followed by the metrics of the tiles.
 func (frame *Frame) ToBuffer() []string {
-	buffer := make([]string, 0, 3)
-	buffer = append(buffer, strconv.Itoa(frame.OrdinalNumber))
-	buffer = append(buffer, strconv.FormatFloat(frame.Brightness, 'f', -1, 64))
-	buffer = append(buffer, strconv.FormatFloat(frame.ColorDifference, 'f', -1, 64))
-	buffer = append(buffer, strconv.FormatFloat(frame.BinaryThresholdDifference, 'f', -1, 64))
+	names := frame.GetMetricsNames()
+	buffer := make([]string, 0, len(names)+6)
+	buffer = append(buffer,
+		strconv.Itoa(frame.OrdinalNumber),
+		strconv.FormatFloat(frame.Timestamp, 'f', -1, 64),
+		frame.FormatTime(),
+		strconv.FormatFloat(frame.ShiftX, 'f', -1, 64),
+		strconv.FormatFloat(frame.ShiftY, 'f', -1, 64),
+		strconv.FormatFloat(frame.SceneChange, 'f', -1, 64))
+	for _, name := range names {
+		buffer = append(buffer, strconv.FormatFloat(frame.GetMetricValue(name), 'f', -1, 64))
+	}
 
 	return buffer
 }
@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/color"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,9 +16,9 @@ func TestShouldCreateNewFirstFrame(t *testing.T) {
 	frame := CreateNewFrame(a, b, 1)
 
 	assert.NotNil(t, frame)
-	assert.Equal(t, 1.0, frame.Brightness)
-	assert.Equal(t, 0.0, frame.ColorDifference)
-	assert.Equal(t, 0.0, frame.BinaryThresholdDifference)
+	assert.Equal(t, 1.0, frame.GetMetricValue(BrightnessMetricName))
+	assert.Equal(t, 0.0, frame.GetMetricValue(ColorDifferenceMetricName))
+	assert.Equal(t, 0.0, frame.GetMetricValue(BinaryThresholdDifferenceMetricName))
 }
 
 func TestShouldCreateNewFrameWithDifferentNeighbour(t *testing.T) {
@@ -27,9 +28,9 @@ func TestShouldCreateNewFrameWithDifferentNeighbour(t *testing.T) {
 	frame := CreateNewFrame(a, b, 2)
 
 	assert.NotNil(t, frame)
-	assert.Equal(t, 1.0, frame.Brightness)
-	assert.Equal(t, 1.0, frame.ColorDifference)
-	assert.Equal(t, 1.0, frame.BinaryThresholdDifference)
+	assert.Equal(t, 1.0, frame.GetMetricValue(BrightnessMetricName))
+	assert.Equal(t, 1.0, frame.GetMetricValue(ColorDifferenceMetricName))
+	assert.Equal(t, 1.0, frame.GetMetricValue(BinaryThresholdDifferenceMetricName))
 }
 
 func TestShouldCreateNewFrameWithIdenticalNeighbour(t *testing.T) {
@@ -39,20 +40,47 @@ func TestShouldCreateNewFrameWithIdenticalNeighbour(t *testing.T) {
 	frame := CreateNewFrame(a, b, 2)
 
 	assert.NotNil(t, frame)
-	assert.Equal(t, 1.0, frame.Brightness)
-	assert.Equal(t, 0.0, frame.ColorDifference)
-	assert.Equal(t, 0.0, frame.BinaryThresholdDifference)
+	assert.Equal(t, 1.0, frame.GetMetricValue(BrightnessMetricName))
+	assert.Equal(t, 0.0, frame.GetMetricValue(ColorDifferenceMetricName))
+	assert.Equal(t, 0.0, frame.GetMetricValue(BinaryThresholdDifferenceMetricName))
+}
+
+func TestShouldCreateNewMaskedFrameOnlyFromIncludedPixels(t *testing.T) {
+	a := mockImage(color.White)
+	b := mockImage(color.Black)
+	for y := 0; y < 4; y += 1 {
+		a.(*image.RGBA).Set(0, y, color.Black)
+	}
+
+	mask, err := CreateNewFrameMask(4, 4, 1.0, nil, []Region{RectangleRegion{X: 0, Y: 0, Width: 1, Height: 4}}, nil)
+	assert.Nil(t, err)
+
+	frame := CreateNewMaskedFrame(a, b, 2, mask)
+
+	assert.NotNil(t, frame)
+	assert.Equal(t, 1.0, frame.GetMetricValue(BrightnessMetricName))
+	assert.Equal(t, 1.0, frame.GetMetricValue(ColorDifferenceMetricName))
+	assert.Equal(t, 1.0, frame.GetMetricValue(BinaryThresholdDifferenceMetricName))
 }
 
 func TestShouldCorrectlyConvertFrameToBuffer(t *testing.T) {
 	a := mockImage(color.White)
 	b := mockImage(color.Black)
 
-	expected := []string{"2", "1", "1", "1"}
+	expected := []string{"2", "0", "", "0", "0", "0", "1", "1", "1"}
 
 	frame := CreateNewFrame(a, b, 2)
 
 	assert.Equal(t, expected, frame.ToBuffer())
+
+	frameTime := time.Date(2024, 6, 1, 21, 30, 5, 500000000, time.UTC)
+	frame.Timestamp = 0.04
+	frame.Time = &frameTime
+	frame.ShiftX = 4
+	frame.ShiftY = -2.5
+	frame.SceneChange = 0.75
+
+	assert.Equal(t, []string{"2", "0.04", "2024-06-01T21:30:05.5Z", "4", "-2.5", "0.75", "1", "1", "1"}, frame.ToBuffer())
 }
 
 func mockImage(c color.Color) image.Image {
@@ -0,0 +1,126 @@
+package frame
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/Krzysztofz01/pimit"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+// Structure representing a connected region of the frame pixels which binary threshold changed compared to the reference frame.
+// The bounding box is specified by the top-left corner and the size, the centroid is the mean position of the region pixels and
+// the area is the number of the region pixels.
+type ChangedRegion struct {
+	X         int     `json:"x"`
+	Y         int     `json:"y"`
+	Width     int     `json:"width"`
+	Height    int     `json:"height"`
+	CentroidX float64 `json:"centroid-x"`
+	CentroidY float64 `json:"centroid-y"`
+	Area      int     `json:"area"`
+}
+
+// Return the bounding box of the region as the rectangle.
+func (region ChangedRegion) Bounds() image.Rectangle {
+	return image.Rect(region.X, region.Y, region.X+region.Width, region.Y+region.Height)
+}
+
+// Return the region scaled by the given factor, which is used to convert the region of the scaled frame to the original frame.
+// The scaled bounding box is covering the whole scaled region.
+func (region ChangedRegion) Scale(factor float64) ChangedRegion {
+	x, y := int(math.Floor(float64(region.X)*factor)), int(math.Floor(float64(region.Y)*factor))
+	return ChangedRegion{
+		X:         x,
+		Y:         y,
+		Width:     int(math.Ceil(float64(region.X+region.Width)*factor)) - x,
+		Height:    int(math.Ceil(float64(region.Y+region.Height)*factor)) - y,
+		CentroidX: region.CentroidX * factor,
+		CentroidY: region.CentroidY * factor,
+		Area:      int(math.Round(float64(region.Area) * factor * factor)),
+	}
+}
+
+// Find the 8-connected regions of the pixels which binary threshold changed between the reference frame and the current frame,
+// which is the same change as counted by the binary threshold difference metric. Only the pixels included by the mask are taken
+// under account and a nil mask includes all pixels of the frame. The regions smaller than the minimal area are ignored and the
+// limit of the largest regions is not applied if not positive. The regions are sorted by the area in descending order.
+func FindChangedRegions(currentFrame, referenceFrame image.Image, mask *FrameMask, minArea, limit int) []ChangedRegion {
+	bounds := currentFrame.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	changed := make([]bool, width*height)
+	pimit.ParallelRead(currentFrame, func(x, y int, currentFrameColor color.Color) {
+		if mask != nil && !mask.IsIncluded(x, y) {
+			return
+		}
+
+		thresholdCurrent := utils.BinaryThreshold(currentFrameColor, BinaryThresholdParam)
+		thresholdReference := utils.BinaryThreshold(referenceFrame.At(x, y), BinaryThresholdParam)
+
+		changed[(y-bounds.Min.Y)*width+(x-bounds.Min.X)] = thresholdCurrent != thresholdReference
+	})
+
+	regions := make([]ChangedRegion, 0)
+	stack := make([]int, 0)
+	for index, isChanged := range changed {
+		if !isChanged {
+			continue
+		}
+
+		var (
+			minX, minY = width, height
+			maxX, maxY = -1, -1
+			sumX, sumY = 0.0, 0.0
+			area       = 0
+		)
+
+		changed[index] = false
+		stack = append(stack[:0], index)
+		for len(stack) != 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			x, y := current%width, current/width
+			minX, minY = utils.MinInt(minX, x), utils.MinInt(minY, y)
+			maxX, maxY = utils.MaxInt(maxX, x), utils.MaxInt(maxY, y)
+			sumX, sumY = sumX+float64(x)+0.5, sumY+float64(y)+0.5
+			area += 1
+
+			for ny := utils.MaxInt(y-1, 0); ny <= utils.MinInt(y+1, height-1); ny += 1 {
+				for nx := utils.MaxInt(x-1, 0); nx <= utils.MinInt(x+1, width-1); nx += 1 {
+					if neighbour := ny*width + nx; changed[neighbour] {
+						changed[neighbour] = false
+						stack = append(stack, neighbour)
+					}
+				}
+			}
+		}
+
+		if area < minArea {
+			continue
+		}
+
+		regions = append(regions, ChangedRegion{
+			X:         bounds.Min.X + minX,
+			Y:         bounds.Min.Y + minY,
+			Width:     maxX - minX + 1,
+			Height:    maxY - minY + 1,
+			CentroidX: float64(bounds.Min.X) + sumX/float64(area),
+			CentroidY: float64(bounds.Min.Y) + sumY/float64(area),
+			Area:      area,
+		})
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].Area > regions[j].Area
+	})
+
+	if limit > 0 && len(regions) > limit {
+		regions = regions[:limit]
+	}
+
+	return regions
+}
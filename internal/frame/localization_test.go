@@ -0,0 +1,60 @@
+package frame
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindChangedRegionsShouldReturnLargestRegions(t *testing.T) {
+	reference := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	current := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y += 1 {
+		for x := 0; x < 10; x += 1 {
+			reference.Set(x, y, color.Black)
+			current.Set(x, y, color.Black)
+		}
+	}
+
+	for y := 1; y < 4; y += 1 {
+		for x := 1; x < 5; x += 1 {
+			current.Set(x, y, color.White)
+		}
+	}
+
+	current.Set(7, 7, color.White)
+	current.Set(8, 8, color.White)
+	current.Set(0, 9, color.White)
+
+	regions := FindChangedRegions(current, reference, nil, 1, 0)
+	assert.Len(t, regions, 3)
+	assert.Equal(t, ChangedRegion{X: 1, Y: 1, Width: 4, Height: 3, CentroidX: 3, CentroidY: 2.5, Area: 12}, regions[0])
+	assert.Equal(t, ChangedRegion{X: 7, Y: 7, Width: 2, Height: 2, CentroidX: 8, CentroidY: 8, Area: 2}, regions[1])
+	assert.Equal(t, 1, regions[2].Area)
+
+	regions = FindChangedRegions(current, reference, nil, 2, 1)
+	assert.Len(t, regions, 1)
+	assert.Equal(t, 12, regions[0].Area)
+}
+
+func TestFindChangedRegionsShouldIgnoreExcludedPixels(t *testing.T) {
+	a := mockImage(color.White)
+	b := mockImage(color.Black)
+
+	mask, err := CreateNewFrameMask(4, 4, 1.0, nil, []Region{RectangleRegion{X: 2, Y: 0, Width: 2, Height: 4}}, nil)
+	assert.Nil(t, err)
+
+	regions := FindChangedRegions(a, b, mask, 1, 0)
+	assert.Equal(t, []ChangedRegion{{X: 0, Y: 0, Width: 2, Height: 4, CentroidX: 1, CentroidY: 2, Area: 8}}, regions)
+
+	assert.Empty(t, FindChangedRegions(a, a, nil, 1, 0))
+}
+
+func TestChangedRegionShouldScale(t *testing.T) {
+	region := ChangedRegion{X: 1, Y: 2, Width: 3, Height: 1, CentroidX: 2.5, CentroidY: 2.5, Area: 3}
+
+	assert.Equal(t, ChangedRegion{X: 2, Y: 4, Width: 6, Height: 2, CentroidX: 5, CentroidY: 5, Area: 12}, region.Scale(2))
+	assert.Equal(t, image.Rect(1, 2, 4, 3), region.Bounds())
+}
@@ -0,0 +1,220 @@
+package frame
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+// Region of the frame represented in the original (not scaled) frame pixel coordinates.
+type Region interface {
+	// Return a boolean value representing if the point specified by the coordinates is located inside the region.
+	Contains(x, y float64) bool
+}
+
+// Structure representing a rectangular region specified by the top-left corner and the size.
+type RectangleRegion struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+func (region RectangleRegion) Contains(x, y float64) bool {
+	return x >= region.X && x < region.X+region.Width && y >= region.Y && y < region.Y+region.Height
+}
+
+// Structure representing a polygonal region specified by the ordered vertices.
+type PolygonRegion struct {
+	Vertices [][2]float64
+}
+
+func (region PolygonRegion) Contains(x, y float64) bool {
+	contains := false
+	for i, j := 0, len(region.Vertices)-1; i < len(region.Vertices); j, i = i, i+1 {
+		xi, yi := region.Vertices[i][0], region.Vertices[i][1]
+		xj, yj := region.Vertices[j][0], region.Vertices[j][1]
+
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			contains = !contains
+		}
+	}
+
+	return contains
+}
+
+// Parse the region from the string representation. A rectangle is represented as "x,y,width,height" and a polygon
+// is represented as semicolon separated list of at least three vertices "x1,y1;x2,y2;x3,y3".
+func ParseRegion(value string) (Region, error) {
+	if !strings.Contains(value, ";") {
+		values, err := parseRegionValues(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) != 4 {
+			return nil, errors.New("frame: the rectangle region must be represented by four values")
+		}
+
+		if values[2] <= 0 || values[3] <= 0 {
+			return nil, errors.New("frame: the rectangle region size must be positive")
+		}
+
+		return RectangleRegion{X: values[0], Y: values[1], Width: values[2], Height: values[3]}, nil
+	}
+
+	vertices := make([][2]float64, 0)
+	for _, vertex := range strings.Split(value, ";") {
+		values, err := parseRegionValues(vertex)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) != 2 {
+			return nil, errors.New("frame: the polygon region vertex must be represented by two values")
+		}
+
+		vertices = append(vertices, [2]float64{values[0], values[1]})
+	}
+
+	if len(vertices) < 3 {
+		return nil, errors.New("frame: the polygon region must be represented by at least three vertices")
+	}
+
+	return PolygonRegion{Vertices: vertices}, nil
+}
+
+func parseRegionValues(value string) ([]float64, error) {
+	values := make([]float64, 0, 4)
+	for _, component := range strings.Split(value, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(component), 64)
+		if err != nil {
+			return nil, fmt.Errorf("frame: failed to parse the region value: %w", err)
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// Structure representing a binary mask of the frame pixels which are taken under account during the frame metrics calculation.
+type FrameMask struct {
+	width    int
+	height   int
+	included []bool
+	count    int
+}
+
+// Create a new frame mask for a frame of the given size. The scale is the factor between the frame size and the original
+// frame size in which the regions are specified. If any include regions are provided, only the pixels inside them are
+// included, otherwise all pixels are included. Pixels which are black on the optional mask image or are inside any of the
+// exclude regions are excluded. The mask image is scaled to the frame size.
+func CreateNewFrameMask(width, height int, scale float64, includeRegions, excludeRegions []Region, maskImage image.Image) (*FrameMask, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("frame: the frame mask size must be positive")
+	}
+
+	if scale <= 0 {
+		return nil, errors.New("frame: the frame mask scale must be positive")
+	}
+
+	mask := &FrameMask{
+		width:    width,
+		height:   height,
+		included: make([]bool, width*height),
+		count:    0,
+	}
+
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			originalX := (float64(x) + 0.5) / scale
+			originalY := (float64(y) + 0.5) / scale
+
+			included := len(includeRegions) == 0
+			for _, region := range includeRegions {
+				if region.Contains(originalX, originalY) {
+					included = true
+					break
+				}
+			}
+
+			if included && maskImage != nil {
+				bounds := maskImage.Bounds()
+				maskX := bounds.Min.X + x*bounds.Dx()/width
+				maskY := bounds.Min.Y + y*bounds.Dy()/height
+
+				included = utils.ColorToGrayscale(maskImage.At(maskX, maskY)) >= 0.5
+			}
+
+			for _, region := range excludeRegions {
+				if !included {
+					break
+				}
+
+				if region.Contains(originalX, originalY) {
+					included = false
+				}
+			}
+
+			if included {
+				mask.included[y*width+x] = true
+				mask.count += 1
+			}
+		}
+	}
+
+	if mask.count == 0 {
+		return nil, errors.New("frame: the frame mask excludes all pixels of the frame")
+	}
+
+	return mask, nil
+}
+
+// Return a boolean value representing if the pixel specified by the coordinates is included by the mask.
+func (mask *FrameMask) IsIncluded(x, y int) bool {
+	return mask.included[y*mask.width+x]
+}
+
+// Return the number of pixels included by the mask.
+func (mask *FrameMask) Count() int {
+	return mask.count
+}
+
+// Return the width of the frame mask.
+func (mask *FrameMask) Width() int {
+	return mask.width
+}
+
+// Return the height of the frame mask.
+func (mask *FrameMask) Height() int {
+	return mask.height
+}
+
+// Return the part of the mask specified by the bounds as a new mask of the size of the bounds. The returned mask may not include
+// any pixels.
+func (mask *FrameMask) Crop(bounds image.Rectangle) *FrameMask {
+	bounds = bounds.Intersect(image.Rect(0, 0, mask.width, mask.height))
+
+	cropped := &FrameMask{
+		width:    bounds.Dx(),
+		height:   bounds.Dy(),
+		included: make([]bool, bounds.Dx()*bounds.Dy()),
+		count:    0,
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			if mask.IsIncluded(x, y) {
+				cropped.included[(y-bounds.Min.Y)*cropped.width+(x-bounds.Min.X)] = true
+				cropped.count += 1
+			}
+		}
+	}
+
+	return cropped
+}
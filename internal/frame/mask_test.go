@@ -0,0 +1,106 @@
+package frame
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldParseRectangleRegion(t *testing.T) {
+	region, err := ParseRegion("1, 2, 3, 4")
+
+	assert.Nil(t, err)
+	assert.Equal(t, RectangleRegion{X: 1, Y: 2, Width: 3, Height: 4}, region)
+}
+
+func TestShouldParsePolygonRegion(t *testing.T) {
+	region, err := ParseRegion("0,0;4,0;4,4")
+
+	assert.Nil(t, err)
+	assert.Equal(t, PolygonRegion{Vertices: [][2]float64{{0, 0}, {4, 0}, {4, 4}}}, region)
+}
+
+func TestShouldNotParseInvalidRegion(t *testing.T) {
+	cases := []string{"", "1,2,3", "1,2,-3,4", "1,x,3,4", "0,0;4,0", "0,0;4;4,4"}
+
+	for _, value := range cases {
+		region, err := ParseRegion(value)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, region)
+	}
+}
+
+func TestRegionShouldContainPoints(t *testing.T) {
+	rectangle := RectangleRegion{X: 1, Y: 1, Width: 2, Height: 2}
+	assert.True(t, rectangle.Contains(1, 1))
+	assert.True(t, rectangle.Contains(2.5, 2.5))
+	assert.False(t, rectangle.Contains(3, 3))
+	assert.False(t, rectangle.Contains(0.5, 1.5))
+
+	polygon := PolygonRegion{Vertices: [][2]float64{{0, 0}, {4, 0}, {0, 4}}}
+	assert.True(t, polygon.Contains(1, 1))
+	assert.False(t, polygon.Contains(3, 3))
+}
+
+func TestFrameMaskShouldIncludeAllPixelsWithoutRegions(t *testing.T) {
+	mask, err := CreateNewFrameMask(4, 4, 1.0, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 16, mask.Count())
+}
+
+func TestFrameMaskShouldRespectIncludeAndExcludeRegions(t *testing.T) {
+	include := []Region{RectangleRegion{X: 0, Y: 0, Width: 4, Height: 2}}
+	exclude := []Region{RectangleRegion{X: 0, Y: 0, Width: 2, Height: 2}}
+
+	mask, err := CreateNewFrameMask(4, 4, 1.0, include, exclude, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, mask.Count())
+	assert.False(t, mask.IsIncluded(0, 0))
+	assert.True(t, mask.IsIncluded(2, 0))
+	assert.False(t, mask.IsIncluded(2, 2))
+}
+
+func TestFrameMaskShouldScaleRegions(t *testing.T) {
+	exclude := []Region{RectangleRegion{X: 0, Y: 0, Width: 4, Height: 4}}
+
+	mask, err := CreateNewFrameMask(4, 4, 0.5, nil, exclude, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 12, mask.Count())
+	assert.False(t, mask.IsIncluded(1, 1))
+	assert.True(t, mask.IsIncluded(2, 1))
+}
+
+func TestFrameMaskShouldRespectMaskImage(t *testing.T) {
+	maskImage := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x += 1 {
+		for y := 0; y < 8; y += 1 {
+			if x < 4 {
+				maskImage.Set(x, y, color.White)
+			} else {
+				maskImage.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	mask, err := CreateNewFrameMask(4, 4, 1.0, nil, nil, maskImage)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 8, mask.Count())
+	assert.True(t, mask.IsIncluded(1, 3))
+	assert.False(t, mask.IsIncluded(2, 3))
+}
+
+func TestFrameMaskShouldNotBeCreatedWhenAllPixelsAreExcluded(t *testing.T) {
+	exclude := []Region{RectangleRegion{X: 0, Y: 0, Width: 4, Height: 4}}
+
+	mask, err := CreateNewFrameMask(4, 4, 1.0, nil, exclude, nil)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, mask)
+}
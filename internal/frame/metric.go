@@ -0,0 +1,160 @@
+package frame
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/Krzysztofz01/pimit"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+	"go.uber.org/atomic"
+)
+
+const (
+	// TODO: Implement different threshold for day/night. The brightness value can be used as the determinant.
+	BinaryThresholdParam float64 = 0.784313725
+
+	BrightnessMetricName                string = "brightness"
+	ColorDifferenceMetricName           string = "color-difference"
+	BinaryThresholdDifferenceMetricName string = "binary-threshold-difference"
+)
+
+// Frame metric calculated for each frame based on the current and the previous frame images.
+type Metric interface {
+	// Return the unique name of the metric used as the key of the metric values.
+	Name() string
+
+	// Calculate the metric value of the current frame taking under account only the pixels included by the mask. The previous
+	// frame is nil for the first frame of the video. A nil mask includes all pixels of the frame.
+	Compute(currentFrame, previousFrame image.Image, mask *FrameMask) float64
+
+	// Return the detection threshold of the metric used when the threshold is not specified.
+	DefaultThreshold() float64
+}
+
+var metrics = []Metric{
+	&brightnessMetric{},
+	&colorDifferenceMetric{},
+	&binaryThresholdDifferenceMetric{},
+}
+
+// Return the ordered collection of the metrics calculated for each frame.
+func GetMetrics() []Metric {
+	return append([]Metric{}, metrics...)
+}
+
+// Return the ordered collection of the names of the metrics calculated for each frame.
+func GetMetricsNames() []string {
+	names := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		names = append(names, metric.Name())
+	}
+
+	return names
+}
+
+// Return a boolean value representing if the metric specified by the name is calculated for each frame.
+func IsMetricName(name string) bool {
+	for _, metric := range metrics {
+		if metric.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type brightnessMetric struct{}
+
+func (metric *brightnessMetric) Name() string {
+	return BrightnessMetricName
+}
+
+func (metric *brightnessMetric) DefaultThreshold() float64 {
+	return 0.0
+}
+
+func (metric *brightnessMetric) Compute(currentFrame, _ image.Image, mask *FrameMask) float64 {
+	brightness := atomic.NewFloat64(0.0)
+	pimit.ParallelRead(currentFrame, func(x, y int, c color.Color) {
+		if mask != nil && !mask.IsIncluded(x, y) {
+			return
+		}
+
+		brightness.Add(utils.GetColorBrightness(c))
+	})
+
+	return brightness.Load() / float64(getFrameSize(currentFrame, mask))
+}
+
+type colorDifferenceMetric struct{}
+
+func (metric *colorDifferenceMetric) Name() string {
+	return ColorDifferenceMetricName
+}
+
+func (metric *colorDifferenceMetric) DefaultThreshold() float64 {
+	return 0.0
+}
+
+func (metric *colorDifferenceMetric) Compute(currentFrame, previousFrame image.Image, mask *FrameMask) float64 {
+	if previousFrame == nil {
+		return 0.0
+	}
+
+	difference := atomic.NewFloat64(0.0)
+	pimit.ParallelRead(currentFrame, func(x, y int, currentFrameColor color.Color) {
+		if mask != nil && !mask.IsIncluded(x, y) {
+			return
+		}
+
+		previousFrameColor := previousFrame.At(x, y)
+
+		difference.Add(utils.GetColorDifference(currentFrameColor, previousFrameColor))
+	})
+
+	return difference.Load() / float64(getFrameSize(currentFrame, mask))
+}
+
+type binaryThresholdDifferenceMetric struct{}
+
+func (metric *binaryThresholdDifferenceMetric) Name() string {
+	return BinaryThresholdDifferenceMetricName
+}
+
+func (metric *binaryThresholdDifferenceMetric) DefaultThreshold() float64 {
+	return 0.0
+}
+
+// TODO: When it coms to BinaryThreshold we need to test which approach gives better results.
+// Currently we are comparing the BT of the previous and current frame and than calcualte the white_pixels / all_pixels
+// Alternatively we can just count the occurance of white pixels and return the non-normalized result
+func (metric *binaryThresholdDifferenceMetric) Compute(currentFrame, previousFrame image.Image, mask *FrameMask) float64 {
+	if previousFrame == nil {
+		return 0.0
+	}
+
+	difference := atomic.NewInt32(0)
+	pimit.ParallelRead(currentFrame, func(x, y int, currentFrameColor color.Color) {
+		if mask != nil && !mask.IsIncluded(x, y) {
+			return
+		}
+
+		thresholdCurrent := utils.BinaryThreshold(currentFrameColor, BinaryThresholdParam)
+		thresholdPrevious := utils.BinaryThreshold(previousFrame.At(x, y), BinaryThresholdParam)
+
+		if thresholdCurrent != thresholdPrevious {
+			difference.Add(1)
+		}
+	})
+
+	return float64(difference.Load()) / float64(getFrameSize(currentFrame, mask))
+}
+
+// Return the number of frame pixels taken under account by the metrics calculation.
+func getFrameSize(frame image.Image, mask *FrameMask) int {
+	if mask != nil {
+		return mask.Count()
+	}
+
+	return frame.Bounds().Dx() * frame.Bounds().Dy()
+}
@@ -0,0 +1,51 @@
+package frame
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsShouldHaveUniqueNames(t *testing.T) {
+	names := GetMetricsNames()
+	unique := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		assert.NotEmpty(t, name)
+		assert.False(t, unique[name])
+
+		unique[name] = true
+	}
+
+	assert.Len(t, names, len(GetMetrics()))
+}
+
+func TestMetricsShouldReturnZeroDifferenceForMissingPreviousFrame(t *testing.T) {
+	a := mockImage(color.White)
+
+	for _, metric := range GetMetrics() {
+		if metric.Name() == BrightnessMetricName {
+			assert.Equal(t, 1.0, metric.Compute(a, nil, nil))
+		} else {
+			assert.Equal(t, 0.0, metric.Compute(a, nil, nil))
+		}
+	}
+}
+
+func TestFrameShouldContainValuesOfAllMetrics(t *testing.T) {
+	frame := CreateNewFrame(mockImage(color.White), mockImage(color.Black), 2)
+
+	for _, name := range GetMetricsNames() {
+		assert.Contains(t, frame.Metrics, name)
+	}
+}
+
+func TestMetricsShouldRecognizeMetricNames(t *testing.T) {
+	for _, name := range GetMetricsNames() {
+		assert.True(t, IsMetricName(name))
+	}
+
+	assert.False(t, IsMetricName("unknown"))
+	assert.False(t, IsMetricName(""))
+}
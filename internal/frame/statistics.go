@@ -5,95 +5,201 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
 )
 
-// Structure containing frames descriptive statistics values.
+// Structure containing descriptive statistics values of a single frame metric. The percentiles are stored by the names
+// created from the percentile values (e.g. "p95").
+type MetricStatistics struct {
+	Mean                    float64            `json:"mean"`
+	MovingMean              []float64          `json:"moving-mean"`
+	StandardDeviation       float64            `json:"standard-deviation"`
+	MovingStandardDeviation []float64          `json:"moving-standard-deviation"`
+	Max                     float64            `json:"max"`
+	Median                  float64            `json:"median"`
+	MovingMedian            []float64          `json:"moving-median"`
+	MedianAbsoluteDeviation float64            `json:"median-absolute-deviation"`
+	Percentiles             map[string]float64 `json:"percentiles"`
+}
+
+// Return the name of the percentile statistic value for the given percentile (e.g. "p95").
+func GetPercentileName(percentile float64) string {
+	return "p" + strconv.FormatFloat(percentile, 'f', -1, 64)
+}
+
+// Structure containing frames descriptive statistics values stored by the metrics names. The tiles is the number of the tiles
+// of the frames grid, which statistics are stored by the tiles metrics names, and is zero if the frames are not split into tiles.
+// The ordinal numbers are the numbers of the frames corresponding to the moving statistics values, which are not consecutive if
+// only a range or every n-th frame of the video was analyzed.
 type FramesStatistics struct {
-	BrightnessMean                             float64   `json:"brightness-mean"`
-	BrightnessMovingMean                       []float64 `json:"brightness-moving-mean"`
-	BrightnessStandardDeviation                float64   `json:"brightness-standard-deviation"`
-	BrightnessMax                              float64   `json:"brightness-max"`
-	ColorDifferenceMean                        float64   `json:"color-difference-mean"`
-	ColorDifferenceMovingMean                  []float64 `json:"color-difference-moving-mean"`
-	ColorDifferenceStandardDeviation           float64   `json:"color-difference-standard-deviation"`
-	ColorDifferenceMax                         float64   `json:"color-difference-max"`
-	BinaryThresholdDifferenceMean              float64   `json:"binary-threshold-difference-mean"`
-	BinaryThresholdDifferenceMovingMean        []float64 `json:"binary-threshold-difference-moving-mean"`
-	BinaryThresholdDifferenceStandardDeviation float64   `json:"binary-threshold-difference-standard-deviation"`
-	BinaryThresholdDifferenceMax               float64   `json:"binary-threshold-difference-max"`
+	Metrics        map[string]*MetricStatistics `json:"metrics"`
+	Tiles          int                          `json:"tiles,omitempty"`
+	OrdinalNumbers []int                        `json:"ordinal-numbers"`
 }
 
+// Create the descriptive statistics of the frames. The moving mean and moving median are calculated on the subsets of the
+// given resolution and the percentiles values must be between zero and one hundred. The statistics of the tiles metrics are
+// calculated if the frames are split into tiles.
 // TODO: movingMeanResolution validation > 1
-func CreateNewFramesStatistics(frames []*Frame, movingMeanResolution int) *FramesStatistics {
+func CreateNewFramesStatistics(frames []*Frame, movingMeanResolution int, percentiles ...float64) *FramesStatistics {
+	statistics := &FramesStatistics{
+		Metrics:        make(map[string]*MetricStatistics),
+		OrdinalNumbers: make([]int, 0, len(frames)),
+	}
+
+	if len(frames) != 0 {
+		statistics.Tiles = frames[0].Tiles
+	}
+
+	for _, frame := range frames {
+		statistics.OrdinalNumbers = append(statistics.OrdinalNumbers, frame.OrdinalNumber)
+	}
+
+	for _, name := range statistics.GetMetricsNames() {
+		statistics.Metrics[name] = createNewMetricStatistics(frames, name, movingMeanResolution, percentiles)
+	}
+
+	return statistics
+}
+
+func createNewMetricStatistics(frames []*Frame, name string, movingMeanResolution int, percentiles []float64) *MetricStatistics {
 	var (
-		movingMeanBias                int       = movingMeanResolution / 2
-		brightness                    []float64 = make([]float64, 0, len(frames))
-		colorDiff                     []float64 = make([]float64, 0, len(frames))
-		binaryThresholdDiff           []float64 = make([]float64, 0, len(frames))
-		brightnessMovingMean          []float64 = make([]float64, 0, len(frames))
-		colorDiffMovingMean           []float64 = make([]float64, 0, len(frames))
-		binaryThresholdDiffMovingMean []float64 = make([]float64, 0, len(frames))
+		movingMeanBias int       = movingMeanResolution / 2
+		values         []float64 = make([]float64, 0, len(frames))
+		movingMean     []float64 = make([]float64, 0, len(frames))
+		movingMedian   []float64 = make([]float64, 0, len(frames))
+		movingStdDev   []float64 = make([]float64, 0, len(frames))
 	)
 
 	for _, frame := range frames {
-		brightness = append(brightness, frame.Brightness)
-		colorDiff = append(colorDiff, frame.ColorDifference)
-		binaryThresholdDiff = append(binaryThresholdDiff, frame.BinaryThresholdDifference)
+		values = append(values, frame.GetMetricValue(name))
 	}
 
 	for index := range frames {
-		brightnessMovingMean = append(brightnessMovingMean, utils.MovingMean(brightness, index, movingMeanBias))
-		colorDiffMovingMean = append(colorDiffMovingMean, utils.MovingMean(colorDiff, index, movingMeanBias))
-		binaryThresholdDiffMovingMean = append(binaryThresholdDiffMovingMean, utils.MovingMean(binaryThresholdDiff, index, movingMeanBias))
-	}
-
-	return &FramesStatistics{
-		BrightnessMean:                             utils.Mean(brightness),
-		BrightnessMovingMean:                       brightnessMovingMean,
-		BrightnessStandardDeviation:                utils.StandardDeviation(brightness),
-		BrightnessMax:                              utils.Max(brightness),
-		ColorDifferenceMean:                        utils.Mean(colorDiff),
-		ColorDifferenceMovingMean:                  colorDiffMovingMean,
-		ColorDifferenceStandardDeviation:           utils.StandardDeviation(colorDiff),
-		ColorDifferenceMax:                         utils.Max(colorDiff),
-		BinaryThresholdDifferenceMean:              utils.Mean(binaryThresholdDiff),
-		BinaryThresholdDifferenceMovingMean:        binaryThresholdDiffMovingMean,
-		BinaryThresholdDifferenceStandardDeviation: utils.StandardDeviation(binaryThresholdDiff),
-		BinaryThresholdDifferenceMax:               utils.Max(binaryThresholdDiff),
+		movingMean = append(movingMean, utils.MovingMean(values, index, movingMeanBias))
+		movingMedian = append(movingMedian, utils.MovingMedian(values, index, movingMeanBias))
+		movingStdDev = append(movingStdDev, utils.MovingStandardDeviation(values, index, movingMeanBias))
+	}
+
+	percentilesValues := make(map[string]float64, len(percentiles))
+	for _, percentile := range percentiles {
+		percentilesValues[GetPercentileName(percentile)] = utils.Percentile(values, percentile)
+	}
+
+	return &MetricStatistics{
+		Mean:                    utils.Mean(values),
+		MovingMean:              movingMean,
+		StandardDeviation:       utils.StandardDeviation(values),
+		MovingStandardDeviation: movingStdDev,
+		Max:                     utils.Max(values),
+		Median:                  utils.Median(values),
+		MovingMedian:            movingMedian,
+		MedianAbsoluteDeviation: utils.MedianAbsoluteDeviation(values),
+		Percentiles:             percentilesValues,
+	}
+}
+
+// Return the names of the percentiles statistics values in ascending order of the percentiles.
+func (statistics *MetricStatistics) GetPercentilesNames() []string {
+	percentiles := make([]float64, 0, len(statistics.Percentiles))
+	for name := range statistics.Percentiles {
+		percentile, err := strconv.ParseFloat(strings.TrimPrefix(name, "p"), 64)
+		if err != nil {
+			panic(fmt.Sprintf("frame: invalid percentile statistic name %s", name))
+		}
+
+		percentiles = append(percentiles, percentile)
+	}
+
+	sort.Float64s(percentiles)
+
+	names := make([]string, 0, len(percentiles))
+	for _, percentile := range percentiles {
+		names = append(names, GetPercentileName(percentile))
 	}
+
+	return names
+}
+
+// Return the ordered collection of the names of the metrics which statistics are stored, including the metrics of the tiles.
+func (statistics *FramesStatistics) GetMetricsNames() []string {
+	return GetTiledMetricsNames(statistics.Tiles)
+}
+
+// Return the descriptive statistics of the metric specified by the name. Panic if the metric statistics are not present.
+func (statistics *FramesStatistics) GetMetricStatistics(name string) *MetricStatistics {
+	metricStatistics, ok := statistics.Metrics[name]
+	if !ok {
+		panic(fmt.Sprintf("frame: missing statistics of the %s metric", name))
+	}
+
+	return metricStatistics
 }
 
 // Write the CSV format statistics report to the provided writer which can be a file reference.
 func (statistics *FramesStatistics) ExportCsvReport(file io.Writer) error {
 	csvWriter := csv.NewWriter(file)
-	rows := [][]string{
-		{"", "Brightness mean", "Brightness standard deviation", "Brightness max"},
-		statistics.valuesToBuffer(1, statistics.BrightnessMean, statistics.BrightnessStandardDeviation, statistics.BrightnessMax),
-		{},
-		{"", "Color difference mean", "Color difference standard deviation", "Color difference max"},
-		statistics.valuesToBuffer(1, statistics.ColorDifferenceMean, statistics.ColorDifferenceStandardDeviation, statistics.ColorDifferenceMax),
-		{},
-		{"", "Binary threshold difference mean", "Binary threshold difference standard deviation", "Binary threshold difference max"},
-		statistics.valuesToBuffer(1, statistics.BinaryThresholdDifferenceMean, statistics.BinaryThresholdDifferenceStandardDeviation, statistics.BinaryThresholdDifferenceMax),
-		{},
-	}
-
-	for _, row := range rows {
-		if err := csvWriter.Write(row); err != nil {
-			return fmt.Errorf("frame: failed to write descriptive statistics to the report file: %w", err)
+	names := statistics.GetMetricsNames()
+
+	for _, name := range names {
+		metricStatistics := statistics.GetMetricStatistics(name)
+
+		header := []string{"", name + " mean", name + " standard deviation", name + " max", name + " median", name + " median absolute deviation"}
+		values := []float64{metricStatistics.Mean, metricStatistics.StandardDeviation, metricStatistics.Max, metricStatistics.Median, metricStatistics.MedianAbsoluteDeviation}
+		for _, percentileName := range metricStatistics.GetPercentilesNames() {
+			header = append(header, name+" "+percentileName)
+			values = append(values, metricStatistics.Percentiles[percentileName])
+		}
+
+		rows := [][]string{
+			header,
+			statistics.valuesToBuffer(1, values...),
+			{},
+		}
+
+		for _, row := range rows {
+			if err := csvWriter.Write(row); err != nil {
+				return fmt.Errorf("frame: failed to write descriptive statistics to the report file: %w", err)
+			}
 		}
 	}
 
-	if err := csvWriter.Write([]string{"Frame (Moving mean center point)", "Brightness moving mean", "ColorDifference moving mean", "BinaryThresholdDifference moving mean"}); err != nil {
+	header := []string{"Frame (Moving mean center point)"}
+	for _, name := range names {
+		header = append(header, name+" moving mean")
+	}
+
+	for _, name := range names {
+		header = append(header, name+" moving median")
+	}
+
+	for _, name := range names {
+		header = append(header, name+" moving standard deviation")
+	}
+
+	if err := csvWriter.Write(header); err != nil {
 		return fmt.Errorf("frame: failed to write the moving mean header to the statistics report file: %w", err)
 	}
 
-	for index := 0; index < len(statistics.BrightnessMovingMean); index += 1 {
-		values := statistics.valuesToBuffer(0, statistics.BrightnessMovingMean[index], statistics.ColorDifferenceMovingMean[index], statistics.BinaryThresholdDifferenceMovingMean[index])
-		if err := csvWriter.Write(append([]string{strconv.Itoa(index + 1)}, values...)); err != nil {
+	for index, ordinalNumber := range statistics.OrdinalNumbers {
+		values := make([]float64, 0, 3*len(names))
+		for _, name := range names {
+			values = append(values, statistics.GetMetricStatistics(name).MovingMean[index])
+		}
+
+		for _, name := range names {
+			values = append(values, statistics.GetMetricStatistics(name).MovingMedian[index])
+		}
+
+		for _, name := range names {
+			values = append(values, statistics.GetMetricStatistics(name).MovingStandardDeviation[index])
+		}
+
+		if err := csvWriter.Write(append([]string{strconv.Itoa(ordinalNumber)}, statistics.valuesToBuffer(0, values...)...)); err != nil {
 			return fmt.Errorf("frame: failed to write moving mean row to the statistics report file: %w", err)
 		}
 	}
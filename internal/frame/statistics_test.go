@@ -2,6 +2,7 @@ package frame
 
 import (
 	"bytes"
+	"encoding/csv"
 	"image/color"
 	"testing"
 
@@ -17,18 +18,19 @@ func TestFrameStatisticsShouldCreate(t *testing.T) {
 	statistics := CreateNewFramesStatistics(frames, 50)
 	assert.NotNil(t, statistics)
 
-	assert.Equal(t, statistics.BrightnessMean, 0.5)
-	assert.Equal(t, statistics.BrightnessStandardDeviation, 0.5)
-	assert.Equal(t, statistics.BrightnessMax, 1.0)
-	assert.Equal(t, statistics.BrightnessMovingMean, []float64{0.5, 0.5})
-	assert.Equal(t, statistics.ColorDifferenceMean, 0.5)
-	assert.Equal(t, statistics.ColorDifferenceStandardDeviation, 0.5)
-	assert.Equal(t, statistics.ColorDifferenceMax, 1.0)
-	assert.Equal(t, statistics.ColorDifferenceMovingMean, []float64{0.5, 0.5})
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMean, 0.5)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceStandardDeviation, 0.5)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMax, 1.0)
-	assert.Equal(t, statistics.BinaryThresholdDifferenceMovingMean, []float64{0.5, 0.5})
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).MovingMean, []float64{0.5, 0.5})
+	assert.Equal(t, statistics.GetMetricStatistics(BrightnessMetricName).MovingStandardDeviation, []float64{0.5, 0.5})
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(ColorDifferenceMetricName).MovingMean, []float64{0.5, 0.5})
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).Mean, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).StandardDeviation, 0.5)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).Max, 1.0)
+	assert.Equal(t, statistics.GetMetricStatistics(BinaryThresholdDifferenceMetricName).MovingMean, []float64{0.5, 0.5})
 }
 
 func TestFramesStatisticsShouldExportCsvReport(t *testing.T) {
@@ -48,3 +50,58 @@ func TestFramesStatisticsShouldExportCsvReport(t *testing.T) {
 
 	assert.NotZero(t, buffer.Len())
 }
+
+func TestFrameStatisticsShouldCreateRobustStatistics(t *testing.T) {
+	frames := make([]*Frame, 0, 10)
+	for index := 0; index < 10; index += 1 {
+		value := float64(index%3) * 0.1
+		if index == 5 {
+			value = 1.0
+		}
+
+		frames = append(frames, &Frame{
+			OrdinalNumber: index + 1,
+			Metrics: map[string]float64{
+				BrightnessMetricName:                value,
+				ColorDifferenceMetricName:           value,
+				BinaryThresholdDifferenceMetricName: value,
+			},
+		})
+	}
+
+	statistics := CreateNewFramesStatistics(frames, 3, 90, 99.5, 50)
+	metricStatistics := statistics.GetMetricStatistics(BrightnessMetricName)
+
+	const delta float64 = 1e-7
+
+	assert.InDelta(t, 0.1, metricStatistics.Median, delta)
+	assert.InDelta(t, 0.1, metricStatistics.MedianAbsoluteDeviation, delta)
+	assert.InDelta(t, 0.1, metricStatistics.MovingMedian[5], delta)
+	assert.Len(t, metricStatistics.MovingMedian, 10)
+	assert.InDelta(t, 0.1, metricStatistics.Percentiles["p50"], delta)
+	assert.InDelta(t, 0.28, metricStatistics.Percentiles["p90"], delta)
+	assert.Equal(t, []string{"p50", "p90", "p99.5"}, metricStatistics.GetPercentilesNames())
+}
+
+func TestFramesStatisticsShouldExportCsvReportWithFramesOrdinalNumbers(t *testing.T) {
+	frames := make([]*Frame, 0, 4)
+	for _, ordinalNumber := range []int{5, 8, 11, 14} {
+		frames = append(frames, CreateNewFrame(mockImage(color.White), mockImage(color.Black), ordinalNumber))
+	}
+
+	statistics := CreateNewFramesStatistics(frames, 3)
+	assert.Equal(t, []int{5, 8, 11, 14}, statistics.OrdinalNumbers)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, statistics.ExportCsvReport(buffer))
+
+	reader := csv.NewReader(buffer)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	assert.Nil(t, err)
+
+	rows := records[len(records)-4:]
+	for index, ordinalNumber := range []string{"5", "8", "11", "14"} {
+		assert.Equal(t, ordinalNumber, rows[index][0])
+	}
+}
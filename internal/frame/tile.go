@@ -0,0 +1,105 @@
+package frame
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// Return the name of the metric calculated for the tile of the frame grid specified by the number. The tiles are numbered from
+// one in the row-major order starting from the top-left tile (e.g. "tile-3-brightness").
+func GetTileMetricName(tile int, name string) string {
+	return fmt.Sprintf("tile-%d-%s", tile, name)
+}
+
+// Return the ordered collection of the names of the metrics calculated for each frame split into the given number of tiles.
+// The names of the metrics of the whole frame are followed by the names of the metrics of the consecutive tiles.
+func GetTiledMetricsNames(tiles int) []string {
+	names := GetMetricsNames()
+	tiledNames := make([]string, 0, len(names)*(tiles+1))
+	tiledNames = append(tiledNames, names...)
+
+	for tile := 1; tile <= tiles; tile += 1 {
+		for _, name := range names {
+			tiledNames = append(tiledNames, GetTileMetricName(tile, name))
+		}
+	}
+
+	return tiledNames
+}
+
+// Return the bounds of the tile specified by the number of the grid of the given columns and rows splitting the frame of the
+// given size. The tiles sizes differ by at most one pixel if the frame size is not divisible by the grid size.
+func GetTileBounds(width, height, columns, rows, tile int) image.Rectangle {
+	column, row := (tile-1)%columns, (tile-1)/columns
+	return image.Rect(
+		column*width/columns,
+		row*height/rows,
+		(column+1)*width/columns,
+		(row+1)*height/rows)
+}
+
+// Create a new frame instance by providing the current and previous frame images, the ordinal number of the frame, the mask of
+// pixels which should be taken under account and the size of the grid splitting the frame into tiles. The metrics are calculated
+// for the whole frame and separately for each tile. The metrics of the empty tiles and the tiles without any pixels included by the mask are zero.
+func CreateNewTiledFrame(currentFrame, previousFrame image.Image, ordinalNumber int, mask *FrameMask, columns, rows int) *Frame {
+	frame := CreateNewMaskedFrame(currentFrame, previousFrame, ordinalNumber, mask)
+	if columns*rows <= 1 {
+		return frame
+	}
+
+	if ordinalNumber == 1 {
+		previousFrame = nil
+	}
+
+	bounds := currentFrame.Bounds()
+	frame.Tiles = columns * rows
+
+	for tile := 1; tile <= frame.Tiles; tile += 1 {
+		tileBounds := GetTileBounds(bounds.Dx(), bounds.Dy(), columns, rows, tile).Add(bounds.Min)
+
+		var tileMask *FrameMask = nil
+		if mask != nil {
+			tileMask = mask.Crop(tileBounds.Sub(bounds.Min))
+		}
+
+		if tileBounds.Empty() || (tileMask != nil && tileMask.Count() == 0) {
+			for _, name := range GetMetricsNames() {
+				frame.Metrics[GetTileMetricName(tile, name)] = 0.0
+			}
+
+			continue
+		}
+
+		var tilePrevious image.Image = nil
+		if previousFrame != nil {
+			tilePrevious = tileImage{src: previousFrame, bounds: tileBounds}
+		}
+
+		values := computeMetrics(tileImage{src: currentFrame, bounds: tileBounds}, tilePrevious, tileMask)
+		for name, value := range values {
+			frame.Metrics[GetTileMetricName(tile, name)] = value
+		}
+	}
+
+	return frame
+}
+
+// Image representing the tile of the source image specified by the bounds. The tile pixels are accessed with the coordinates
+// relative to the top-left corner of the tile, as expected by the metrics.
+type tileImage struct {
+	src    image.Image
+	bounds image.Rectangle
+}
+
+func (img tileImage) ColorModel() color.Model {
+	return img.src.ColorModel()
+}
+
+func (img tileImage) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.bounds.Dx(), img.bounds.Dy())
+}
+
+func (img tileImage) At(x, y int) color.Color {
+	return img.src.At(img.bounds.Min.X+x, img.bounds.Min.Y+y)
+}
@@ -0,0 +1,69 @@
+package frame
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGetTileBounds(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 3, 2), GetTileBounds(10, 5, 3, 2, 1))
+	assert.Equal(t, image.Rect(3, 0, 6, 2), GetTileBounds(10, 5, 3, 2, 2))
+	assert.Equal(t, image.Rect(6, 2, 10, 5), GetTileBounds(10, 5, 3, 2, 6))
+}
+
+func TestShouldGetTiledMetricsNames(t *testing.T) {
+	assert.Equal(t, GetMetricsNames(), GetTiledMetricsNames(0))
+
+	names := GetTiledMetricsNames(2)
+	assert.Len(t, names, 9)
+	assert.Equal(t, "tile-1-brightness", names[3])
+	assert.Equal(t, "tile-2-binary-threshold-difference", names[8])
+}
+
+func TestShouldCreateNewTiledFrame(t *testing.T) {
+	a := mockImage(color.Black)
+	b := mockImage(color.Black)
+	a.(*image.RGBA).Set(3, 3, color.White)
+
+	frame := CreateNewTiledFrame(a, b, 2, nil, 2, 2)
+
+	assert.Equal(t, 4, frame.Tiles)
+	assert.Equal(t, 1.0/16.0, frame.GetMetricValue(BrightnessMetricName))
+	assert.Equal(t, 0.0, frame.GetMetricValue(GetTileMetricName(1, BrightnessMetricName)))
+	assert.Equal(t, 0.25, frame.GetMetricValue(GetTileMetricName(4, BrightnessMetricName)))
+	assert.Equal(t, 0.25, frame.GetMetricValue(GetTileMetricName(4, BinaryThresholdDifferenceMetricName)))
+	assert.Len(t, frame.ToBuffer(), 6+15)
+
+	frame = CreateNewTiledFrame(a, b, 2, nil, 1, 1)
+	assert.Equal(t, 0, frame.Tiles)
+	assert.Len(t, frame.Metrics, 3)
+}
+
+func TestShouldCreateNewTiledFrameWithMaskedTiles(t *testing.T) {
+	a := mockImage(color.White)
+	b := mockImage(color.Black)
+
+	mask, err := CreateNewFrameMask(4, 4, 1.0, []Region{RectangleRegion{X: 0, Y: 0, Width: 2, Height: 4}}, nil, nil)
+	assert.Nil(t, err)
+
+	frame := CreateNewTiledFrame(a, b, 2, mask, 2, 1)
+
+	assert.Equal(t, 1.0, frame.GetMetricValue(GetTileMetricName(1, ColorDifferenceMetricName)))
+	assert.Equal(t, 0.0, frame.GetMetricValue(GetTileMetricName(2, ColorDifferenceMetricName)))
+}
+
+func TestFrameStatisticsShouldCreateTilesStatistics(t *testing.T) {
+	frames := []*Frame{
+		CreateNewTiledFrame(mockImage(color.White), mockImage(color.Black), 1, nil, 2, 1),
+		CreateNewTiledFrame(mockImage(color.Black), mockImage(color.White), 2, nil, 2, 1),
+	}
+
+	statistics := CreateNewFramesStatistics(frames, 50)
+
+	assert.Equal(t, 2, statistics.Tiles)
+	assert.Equal(t, 0.5, statistics.GetMetricStatistics(GetTileMetricName(2, BrightnessMetricName)).Mean)
+	assert.Equal(t, []float64{0.5, 0.5}, statistics.GetMetricStatistics(GetTileMetricName(1, ColorDifferenceMetricName)).MovingMean)
+}
@@ -0,0 +1,140 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+)
+
+const StandardInputPath string = "-"
+
+type rawStreamSource struct {
+	path        string
+	reader      io.ReadCloser
+	width       int
+	height      int
+	fps         float64
+	frames      int
+	frameBuffer *image.RGBA
+	err         error
+}
+
+// Create a new frame source reading the raw RGBA frames of the given size, such as the frames produced by the ffmpeg rawvideo
+// muxer with the rgba pixel format, from the file or the named pipe specified by the path. The frames are read from the standard
+// input if the path is "-". The number of frames is unknown and the frames can be read only sequentially.
+func CreateRawStreamSource(path string, width, height int, fps float64) (FrameSource, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("source: the raw stream frame width and height must be positive")
+	}
+
+	if fps < 0 {
+		return nil, errors.New("source: the raw stream frame rate must not be negative")
+	}
+
+	var reader io.ReadCloser = os.Stdin
+	if path != StandardInputPath {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("source: failed to open the raw stream: %w", err)
+		}
+
+		reader = file
+	}
+
+	return createRawStreamSource(path, reader, width, height, fps), nil
+}
+
+func createRawStreamSource(path string, reader io.ReadCloser, width, height int, fps float64) *rawStreamSource {
+	return &rawStreamSource{
+		path:        path,
+		reader:      reader,
+		width:       width,
+		height:      height,
+		fps:         fps,
+		frames:      0,
+		frameBuffer: image.NewRGBA(image.Rect(0, 0, width, height)),
+		err:         nil,
+	}
+}
+
+func (source *rawStreamSource) Path() string {
+	return source.path
+}
+
+func (source *rawStreamSource) Width() int {
+	return source.width
+}
+
+func (source *rawStreamSource) Height() int {
+	return source.height
+}
+
+func (source *rawStreamSource) Frames() int {
+	return 0
+}
+
+func (source *rawStreamSource) FPS() float64 {
+	return source.fps
+}
+
+func (source *rawStreamSource) Duration() float64 {
+	return 0
+}
+
+func (source *rawStreamSource) Read() bool {
+	if source.err != nil {
+		return false
+	}
+
+	if _, err := io.ReadFull(source.reader, source.frameBuffer.Pix); err != nil {
+		if err != io.EOF {
+			source.err = fmt.Errorf("source: failed to read the raw stream frame %d: %w", source.frames+1, err)
+		}
+
+		return false
+	}
+
+	source.frames += 1
+	return true
+}
+
+func (source *rawStreamSource) Skip() bool {
+	if source.err != nil {
+		return false
+	}
+
+	if count, err := io.CopyN(io.Discard, source.reader, int64(len(source.frameBuffer.Pix))); err != nil {
+		if count != 0 || err != io.EOF {
+			source.err = fmt.Errorf("source: failed to skip the raw stream frame %d: %w", source.frames+1, err)
+		}
+
+		return false
+	}
+
+	source.frames += 1
+	return true
+}
+
+func (source *rawStreamSource) FrameBuffer() *image.RGBA {
+	return source.frameBuffer
+}
+
+func (source *rawStreamSource) Err() error {
+	return source.err
+}
+
+func (source *rawStreamSource) ReadFrames(indexes ...int) ([]*image.RGBA, error) {
+	if len(indexes) == 0 {
+		return []*image.RGBA{}, nil
+	}
+
+	return nil, errors.New("source: the frames of the raw stream can not be read by the indexes")
+}
+
+func (source *rawStreamSource) Close() {
+	if source.reader != os.Stdin {
+		source.reader.Close()
+	}
+}
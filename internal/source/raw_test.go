@@ -0,0 +1,95 @@
+package source
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawStreamSourceShouldReadFramesUntilEndOfStream(t *testing.T) {
+	width, height := 3, 2
+	stream := make([]byte, 0, 3*width*height*4)
+	for frame := 0; frame < 3; frame += 1 {
+		stream = append(stream, bytes.Repeat([]byte{byte(10 * (frame + 1)), 0, 0, 255}, width*height)...)
+	}
+
+	source := createRawStreamSource(StandardInputPath, io.NopCloser(bytes.NewReader(stream)), width, height, 25)
+	defer source.Close()
+
+	assert.Equal(t, 0, source.Frames())
+	assert.Equal(t, 25.0, source.FPS())
+
+	reads := 0
+	for source.Read() {
+		assert.Equal(t, uint8(10*(reads+1)), source.FrameBuffer().RGBAAt(width-1, height-1).R)
+		reads += 1
+	}
+
+	assert.Equal(t, 3, reads)
+	assert.Nil(t, source.Err())
+	assert.False(t, source.Read())
+}
+
+func TestRawStreamSourceShouldFailOnIncompleteFrame(t *testing.T) {
+	source := createRawStreamSource(StandardInputPath, io.NopCloser(bytes.NewReader(make([]byte, 2*2*4+1))), 2, 2, 0)
+	defer source.Close()
+
+	assert.True(t, source.Read())
+	assert.False(t, source.Read())
+	assert.NotNil(t, source.Err())
+}
+
+func TestRawStreamSourceShouldSkipFrames(t *testing.T) {
+	stream := make([]byte, 0, 3*4)
+	for frame := 0; frame < 3; frame += 1 {
+		stream = append(stream, byte(10*(frame+1)), 0, 0, 255)
+	}
+
+	source := createRawStreamSource(StandardInputPath, io.NopCloser(bytes.NewReader(append(stream, 0, 0))), 1, 1, 0)
+	defer source.Close()
+
+	assert.True(t, source.Skip())
+	assert.True(t, source.Read())
+	assert.Equal(t, uint8(20), source.FrameBuffer().RGBAAt(0, 0).R)
+	assert.True(t, source.Skip())
+	assert.False(t, source.Skip())
+	assert.NotNil(t, source.Err())
+}
+
+func TestRawStreamSourceShouldNotReadFramesByIndexes(t *testing.T) {
+	source := createRawStreamSource(StandardInputPath, io.NopCloser(bytes.NewReader([]byte{})), 2, 2, 0)
+	defer source.Close()
+
+	frames, err := source.ReadFrames()
+	assert.Nil(t, err)
+	assert.Empty(t, frames)
+
+	frames, err = source.ReadFrames(0)
+	assert.Nil(t, frames)
+	assert.NotNil(t, err)
+}
+
+func TestRawStreamSourceShouldOpenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stream.rgba")
+	assert.Nil(t, os.WriteFile(path, make([]byte, 2*2*4*2), 0644))
+
+	source, err := CreateRawStreamSource(path, 2, 2, 0)
+	assert.Nil(t, err)
+	defer source.Close()
+
+	assert.Equal(t, path, source.Path())
+	assert.True(t, source.Read())
+	assert.True(t, source.Read())
+	assert.False(t, source.Read())
+	assert.Nil(t, source.Err())
+
+	_, err = CreateRawStreamSource(path, 0, 2, 0)
+	assert.NotNil(t, err)
+
+	_, err = CreateRawStreamSource(filepath.Join(t.TempDir(), "missing.rgba"), 2, 2, 0)
+	assert.NotNil(t, err)
+}
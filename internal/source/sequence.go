@@ -0,0 +1,309 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+)
+
+var printfVerbRegexp = regexp.MustCompile(`%0?(\d*)d`)
+
+type imageSequenceSource struct {
+	path        string
+	files       []string
+	width       int
+	height      int
+	fps         float64
+	index       int
+	frameBuffer *image.RGBA
+	err         error
+}
+
+// Create a new frame source reading the PNG or JPEG images specified by the path, which can be a directory, a glob pattern or a
+// printf pattern with a single integer verb (e.g. "frame-%04d.jpg"). The images are sorted using the natural order of the file names.
+// The images do not store the frame rate, so the frame rate of the sequence is provided explicitly and zero represents an unknown
+// frame rate.
+func CreateImageSequenceSource(path string, fps float64) (FrameSource, error) {
+	if fps < 0 {
+		return nil, errors.New("source: the image sequence frame rate must not be negative")
+	}
+
+	files, err := resolveImageSequenceFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to resolve the image sequence files: %w", err)
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("source: the image sequence does not contain any PNG or JPEG images")
+	}
+
+	first, err := utils.ImportImage(files[0])
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to import the first image of the sequence: %w", err)
+	}
+
+	width, height := first.Bounds().Dx(), first.Bounds().Dy()
+
+	return &imageSequenceSource{
+		path:        path,
+		files:       files,
+		width:       width,
+		height:      height,
+		fps:         fps,
+		index:       0,
+		frameBuffer: image.NewRGBA(image.Rect(0, 0, width, height)),
+		err:         nil,
+	}, nil
+}
+
+func (source *imageSequenceSource) Path() string {
+	return source.path
+}
+
+func (source *imageSequenceSource) Width() int {
+	return source.width
+}
+
+func (source *imageSequenceSource) Height() int {
+	return source.height
+}
+
+func (source *imageSequenceSource) Frames() int {
+	return len(source.files)
+}
+
+func (source *imageSequenceSource) FPS() float64 {
+	return source.fps
+}
+
+func (source *imageSequenceSource) Duration() float64 {
+	if source.fps <= 0 {
+		return 0
+	}
+
+	return float64(len(source.files)) / source.fps
+}
+
+func (source *imageSequenceSource) Read() bool {
+	if source.err != nil || source.index >= len(source.files) {
+		return false
+	}
+
+	if err := source.readImage(source.files[source.index], source.frameBuffer); err != nil {
+		source.err = err
+		return false
+	}
+
+	source.index += 1
+	return true
+}
+
+func (source *imageSequenceSource) Skip() bool {
+	if source.err != nil || source.index >= len(source.files) {
+		return false
+	}
+
+	source.index += 1
+	return true
+}
+
+func (source *imageSequenceSource) FrameBuffer() *image.RGBA {
+	return source.frameBuffer
+}
+
+func (source *imageSequenceSource) Err() error {
+	return source.err
+}
+
+func (source *imageSequenceSource) ReadFrames(indexes ...int) ([]*image.RGBA, error) {
+	frames := make([]*image.RGBA, 0, len(indexes))
+	for _, index := range indexes {
+		if index < 0 || index >= len(source.files) {
+			return nil, fmt.Errorf("source: the frame index %d is out of the image sequence range", index)
+		}
+
+		frame := image.NewRGBA(image.Rect(0, 0, source.width, source.height))
+		if err := source.readImage(source.files[index], frame); err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
+
+func (source *imageSequenceSource) GetFramePath(index int) string {
+	return source.files[index]
+}
+
+func (source *imageSequenceSource) Close() {}
+
+// Helper function used to decode the image file and draw it onto the destination RGBA image.
+func (source *imageSequenceSource) readImage(path string, dst *image.RGBA) error {
+	img, err := utils.ImportImage(path)
+	if err != nil {
+		return fmt.Errorf("source: failed to import the image sequence frame: %w", err)
+	}
+
+	if img.Bounds().Dx() != source.width || img.Bounds().Dy() != source.height {
+		return fmt.Errorf("source: the image sequence frame %s size is not matching the size of the first frame", path)
+	}
+
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Src)
+	return nil
+}
+
+// Helper function used to list the image files of the sequence specified by a directory, a glob pattern, a printf pattern or
+// a single image file path. The files are sorted using the natural order, except the printf pattern which is sorted numerically.
+func resolveImageSequenceFiles(path string) ([]string, error) {
+	if isPrintfPattern(path) {
+		return resolvePrintfPatternFiles(path)
+	}
+
+	var (
+		matches []string
+		err     error
+	)
+
+	if info, statErr := os.Stat(path); statErr == nil && info.IsDir() {
+		matches, err = filepath.Glob(filepath.Join(path, "*"))
+	} else {
+		matches, err = filepath.Glob(path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to match the image sequence files: %w", err)
+	}
+
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() && isImageFile(match) {
+			files = append(files, match)
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return naturalLess(filepath.Base(files[i]), filepath.Base(files[j]))
+	})
+
+	return files, nil
+}
+
+// Helper function used to list the files matching the printf pattern with a single integer verb in the file name.
+func resolvePrintfPatternFiles(path string) ([]string, error) {
+	directory, name := filepath.Split(path)
+	if len(directory) == 0 {
+		directory = "."
+	}
+
+	location := printfVerbRegexp.FindStringSubmatchIndex(name)
+	if location == nil {
+		return nil, fmt.Errorf("source: the %s file name does not contain a printf integer verb", path)
+	}
+
+	digits := `\d+`
+	if width := name[location[2]:location[3]]; len(width) != 0 && strings.HasPrefix(name[location[0]:], "%0") {
+		digits = fmt.Sprintf(`\d{%s,}`, width)
+	}
+
+	pattern, err := regexp.Compile("^" + regexp.QuoteMeta(name[:location[0]]) + "(" + digits + ")" + regexp.QuoteMeta(name[location[1]:]) + "$")
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to compile the printf pattern: %w", err)
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to read the image sequence directory: %w", err)
+	}
+
+	type numberedFile struct {
+		path   string
+		number int
+	}
+
+	numberedFiles := make([]numberedFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isImageFile(entry.Name()) {
+			continue
+		}
+
+		match := pattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("source: failed to parse the image sequence file number: %w", err)
+		}
+
+		numberedFiles = append(numberedFiles, numberedFile{
+			path:   filepath.Join(directory, entry.Name()),
+			number: number,
+		})
+	}
+
+	sort.SliceStable(numberedFiles, func(i, j int) bool {
+		return numberedFiles[i].number < numberedFiles[j].number
+	})
+
+	files := make([]string, 0, len(numberedFiles))
+	for _, file := range numberedFiles {
+		files = append(files, file.path)
+	}
+
+	return files, nil
+}
+
+// Helper function used to determine if the file name of the path contains a printf integer verb.
+func isPrintfPattern(path string) bool {
+	return printfVerbRegexp.MatchString(filepath.Base(path))
+}
+
+// Compare the strings using the natural order, where the sequences of digits are compared by their numeric values.
+func naturalLess(a, b string) bool {
+	for len(a) != 0 && len(b) != 0 {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+
+		if len(aDigits) != 0 && len(bDigits) != 0 {
+			aTrimmed, bTrimmed := strings.TrimLeft(aDigits, "0"), strings.TrimLeft(bDigits, "0")
+			if len(aTrimmed) != len(bTrimmed) {
+				return len(aTrimmed) < len(bTrimmed)
+			}
+
+			if aTrimmed != bTrimmed {
+				return aTrimmed < bTrimmed
+			}
+
+			a, b = a[len(aDigits):], b[len(bDigits):]
+			continue
+		}
+
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	index := 0
+	for index < len(s) && s[index] >= '0' && s[index] <= '9' {
+		index += 1
+	}
+
+	return s[:index]
+}
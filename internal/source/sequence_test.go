@@ -0,0 +1,169 @@
+package source
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaturalLessShouldCompareDigitsByValue(t *testing.T) {
+	names := []string{"img10.png", "img2.png", "img1.png", "img010b.png", "a.png", "img02.png", "img.png"}
+	sort.SliceStable(names, func(i, j int) bool { return naturalLess(names[i], names[j]) })
+
+	assert.Equal(t, []string{"a.png", "img.png", "img1.png", "img2.png", "img02.png", "img10.png", "img010b.png"}, names)
+}
+
+func TestShouldDetectImageSequencePaths(t *testing.T) {
+	directory := t.TempDir()
+
+	assert.True(t, isImageSequencePath(directory))
+	assert.True(t, isImageSequencePath(filepath.Join(directory, "*.jpg")))
+	assert.True(t, isImageSequencePath(filepath.Join(directory, "frame-%04d.png")))
+	assert.True(t, isImageSequencePath(filepath.Join(directory, "frame.JPG")))
+	assert.False(t, isImageSequencePath(filepath.Join(directory, "video.mp4")))
+}
+
+func TestShouldNotDetectExistingVideoFileAsImageSequencePath(t *testing.T) {
+	directory := t.TempDir()
+
+	for _, name := range []string{"clip[1].mp4", "clip?.mp4", "clip-%d.mp4", "frame[1].png"} {
+		assert.Nil(t, os.WriteFile(filepath.Join(directory, name), []byte{}, 0660))
+	}
+
+	assert.False(t, isImageSequencePath(filepath.Join(directory, "clip[1].mp4")))
+	assert.False(t, isImageSequencePath(filepath.Join(directory, "clip?.mp4")))
+	assert.False(t, isImageSequencePath(filepath.Join(directory, "clip-%d.mp4")))
+	assert.True(t, isImageSequencePath(filepath.Join(directory, "frame[1].png")))
+	assert.True(t, isImageSequencePath(filepath.Join(directory, "clip[0-9].mp4")))
+}
+
+func TestImageSequenceSourceShouldReadDirectoryInNaturalOrder(t *testing.T) {
+	directory := t.TempDir()
+	mockImageSequence(t, directory, []string{"frame-10.png", "frame-2.png", "frame-1.png", "notes.txt"})
+
+	source, err := CreateFrameSource(directory, 0)
+	assert.Nil(t, err)
+	defer source.Close()
+
+	assert.Equal(t, 3, source.Frames())
+	assert.Equal(t, 0.0, source.FPS())
+	assert.Equal(t, 0.0, source.Duration())
+	assert.Equal(t, 4, source.Width())
+	assert.Equal(t, 2, source.Height())
+
+	fileSource, ok := source.(FileFrameSource)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(directory, "frame-1.png"), fileSource.GetFramePath(0))
+	assert.Equal(t, filepath.Join(directory, "frame-2.png"), fileSource.GetFramePath(1))
+	assert.Equal(t, filepath.Join(directory, "frame-10.png"), fileSource.GetFramePath(2))
+
+	reads := 0
+	for source.Read() {
+		assert.Equal(t, uint8(10*(reads+1)), source.FrameBuffer().RGBAAt(0, 0).R)
+		reads += 1
+	}
+
+	assert.Equal(t, 3, reads)
+	assert.Nil(t, source.Err())
+}
+
+func TestImageSequenceSourceShouldResolveGlobAndPrintfPatterns(t *testing.T) {
+	directory := t.TempDir()
+	mockImageSequence(t, directory, []string{"sky_0002.png", "sky_0001.png", "sky_0010.png", "other_0003.png"})
+
+	globSource, err := CreateFrameSource(filepath.Join(directory, "sky_*.png"), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, globSource.Frames())
+
+	printfSource, err := CreateFrameSource(filepath.Join(directory, "sky_%04d.png"), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, printfSource.Frames())
+	assert.Equal(t, filepath.Join(directory, "sky_0010.png"), printfSource.(FileFrameSource).GetFramePath(2))
+}
+
+func TestImageSequenceSourceShouldReadFramesByIndexes(t *testing.T) {
+	directory := t.TempDir()
+	mockImageSequence(t, directory, []string{"1.png", "2.png", "3.png"})
+
+	source, err := CreateImageSequenceSource(directory, 0)
+	assert.Nil(t, err)
+
+	frames, err := source.ReadFrames(0, 2)
+	assert.Nil(t, err)
+	assert.Len(t, frames, 2)
+	assert.Equal(t, uint8(10), frames[0].RGBAAt(0, 0).R)
+	assert.Equal(t, uint8(30), frames[1].RGBAAt(0, 0).R)
+
+	_, err = source.ReadFrames(3)
+	assert.NotNil(t, err)
+}
+
+func TestImageSequenceSourceShouldSkipFrames(t *testing.T) {
+	directory := t.TempDir()
+	mockImageSequence(t, directory, []string{"1.png", "2.png", "3.png"})
+
+	source, err := CreateImageSequenceSource(directory, 0)
+	assert.Nil(t, err)
+	defer source.Close()
+
+	assert.True(t, source.Skip())
+	assert.True(t, source.Read())
+	assert.Equal(t, uint8(20), source.FrameBuffer().RGBAAt(0, 0).R)
+	assert.True(t, source.Skip())
+	assert.False(t, source.Skip())
+	assert.False(t, source.Read())
+	assert.Nil(t, source.Err())
+}
+
+func TestImageSequenceSourceShouldNotCreateForEmptySequence(t *testing.T) {
+	source, err := CreateImageSequenceSource(t.TempDir(), 0)
+
+	assert.Nil(t, source)
+	assert.NotNil(t, err)
+}
+
+func TestImageSequenceSourceShouldUseProvidedFrameRate(t *testing.T) {
+	directory := t.TempDir()
+	mockImageSequence(t, directory, []string{"1.png", "2.png", "3.png"})
+
+	source, err := CreateFrameSource(directory, 30)
+	assert.Nil(t, err)
+	defer source.Close()
+
+	assert.Equal(t, 30.0, source.FPS())
+	assert.InDelta(t, 0.1, source.Duration(), 1e-9)
+
+	source, err = CreateImageSequenceSource(directory, -1)
+	assert.Nil(t, source)
+	assert.NotNil(t, err)
+}
+
+// Helper function used to create the given files in the directory. The images are filled with a color which red component
+// is based on the natural order of the image names and the other files are empty.
+func mockImageSequence(t *testing.T, directory string, names []string) {
+	sorted := append([]string{}, names...)
+	sort.SliceStable(sorted, func(i, j int) bool { return naturalLess(sorted[i], sorted[j]) })
+
+	for index, name := range sorted {
+		path := filepath.Join(directory, name)
+		if !isImageFile(name) {
+			assert.Nil(t, os.WriteFile(path, []byte{}, 0660))
+			continue
+		}
+
+		img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+		for x := 0; x < 4; x += 1 {
+			for y := 0; y < 2; y += 1 {
+				img.Set(x, y, color.RGBA{uint8(10 * (index + 1)), 0, 0, 255})
+			}
+		}
+
+		assert.Nil(t, utils.ExportImageAsPng(path, img))
+	}
+}
@@ -0,0 +1,104 @@
+package source
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Source of the consecutive frames images on which the lightning detection is performed. The frames are indexed from zero.
+type FrameSource interface {
+	// Return the path of the source as specified on the creation.
+	Path() string
+
+	// Return the width of the frames in pixels.
+	Width() int
+
+	// Return the height of the frames in pixels.
+	Height() int
+
+	// Return the number of frames provided by the source. Zero is returned if the number of frames is unknown.
+	Frames() int
+
+	// Return the frame rate of the source. Zero is returned if the frame rate is unknown.
+	FPS() float64
+
+	// Return the duration of the source in seconds. Zero is returned if the duration is unknown.
+	Duration() float64
+
+	// Read the next frame and store it in the frame buffer. False is returned if there are no more frames to read or the
+	// frame reading failed, in which case the error is accessible via the Err function.
+	Read() bool
+
+	// Skip the next frame, avoiding the decoding of the frame if possible. The content of the frame buffer is not specified after
+	// the frame is skipped. False is returned if there are no more frames to skip or the frame skipping failed, in which case the
+	// error is accessible via the Err function.
+	Skip() bool
+
+	// Return the frame buffer storing the image of the most recently read frame.
+	FrameBuffer() *image.RGBA
+
+	// Return the error which caused the interruption of the frames reading.
+	Err() error
+
+	// Read the frames specified by the ascending sorted indexes independently of the sequential reading.
+	ReadFrames(indexes ...int) ([]*image.RGBA, error)
+
+	// Release the resources allocated by the source.
+	Close()
+}
+
+// Frame source which frames are stored as separate image files. The original files can be copied instead of re-encoding.
+type FileFrameSource interface {
+	FrameSource
+
+	// Return the path of the image file of the frame specified by the index.
+	GetFramePath(index int) string
+}
+
+// Frame source providing the timing metadata of the container, such as the video files.
+type TimedFrameSource interface {
+	FrameSource
+
+	// Return the presentation timestamp of the first frame in seconds.
+	StartTime() float64
+
+	// Return the creation time of the container. False is returned if the creation time is unknown.
+	CreationTime() (time.Time, bool)
+}
+
+// Create a new frame source based on the provided path. Directories, PNG or JPEG files and, if no such file exists, glob or printf
+// patterns are read as image sequences with the given sequence frame rate, which is zero if unknown, and all other paths as video files.
+func CreateFrameSource(path string, sequenceFPS float64) (FrameSource, error) {
+	if isImageSequencePath(path) {
+		return CreateImageSequenceSource(path, sequenceFPS)
+	}
+
+	return CreateVideoSource(path)
+}
+
+// Helper function used to determine if the given path is representing an image sequence.
+func isImageSequencePath(path string) bool {
+	// NOTE: The existing files are not treated as patterns, so the video files names can contain the patterns characters.
+	if info, err := os.Stat(path); err == nil {
+		return info.IsDir() || isImageFile(path)
+	}
+
+	if strings.ContainsAny(path, "*?[") || isPrintfPattern(path) {
+		return true
+	}
+
+	return isImageFile(path)
+}
+
+// Helper function used to determine if the given file path has a supported image extension.
+func isImageFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	vidio "github.com/AlexEidt/Vidio"
+)
+
+type videoSource struct {
+	path        string
+	video       *vidio.Video
+	frameBuffer *image.RGBA
+}
+
+// Create a new frame source reading the frames of the video file specified by the path.
+func CreateVideoSource(path string) (FrameSource, error) {
+	video, err := vidio.NewVideo(path)
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to open the video file: %w", err)
+	}
+
+	frameBuffer := image.NewRGBA(image.Rect(0, 0, video.Width(), video.Height()))
+	if err := video.SetFrameBuffer(frameBuffer.Pix); err != nil {
+		video.Close()
+		return nil, fmt.Errorf("source: failed to set the video frame buffer: %w", err)
+	}
+
+	return &videoSource{
+		path:        path,
+		video:       video,
+		frameBuffer: frameBuffer,
+	}, nil
+}
+
+func (source *videoSource) Path() string {
+	return source.path
+}
+
+func (source *videoSource) Width() int {
+	return source.video.Width()
+}
+
+func (source *videoSource) Height() int {
+	return source.video.Height()
+}
+
+func (source *videoSource) Frames() int {
+	return source.video.Frames()
+}
+
+func (source *videoSource) FPS() float64 {
+	return source.video.FPS()
+}
+
+func (source *videoSource) Duration() float64 {
+	return source.video.Duration()
+}
+
+func (source *videoSource) StartTime() float64 {
+	return parseVideoStartTime(source.video.MetaData())
+}
+
+func (source *videoSource) CreationTime() (time.Time, bool) {
+	return parseVideoCreationTime(probeVideoFormat(source.path), source.video.MetaData())
+}
+
+func (source *videoSource) Read() bool {
+	return source.video.Read()
+}
+
+func (source *videoSource) Skip() bool {
+	return source.video.Read()
+}
+
+func (source *videoSource) FrameBuffer() *image.RGBA {
+	return source.frameBuffer
+}
+
+func (source *videoSource) Err() error {
+	return nil
+}
+
+func (source *videoSource) ReadFrames(indexes ...int) ([]*image.RGBA, error) {
+	if len(indexes) == 0 {
+		return []*image.RGBA{}, nil
+	}
+
+	frames, err := source.video.ReadFrames(indexes...)
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to read the frames from the video: %w", err)
+	}
+
+	return frames, nil
+}
+
+func (source *videoSource) Close() {
+	source.video.Close()
+}
+
+// Helper function used to parse the presentation timestamp of the first frame from the ffprobe stream metadata. Zero is returned
+// if the start time is not available.
+func parseVideoStartTime(metadata map[string]string) float64 {
+	startTime, err := strconv.ParseFloat(metadata["start_time"], 64)
+	if err != nil {
+		return 0
+	}
+
+	return startTime
+}
+
+// Helper function used to parse the creation time of the video from the ffprobe metadata tags. The creation time of the container
+// stored in the format tags is preferred, because the muxers often do not copy it to the stream tags, and the stream creation
+// time is used as a fallback. False is returned if the creation time is not available.
+func parseVideoCreationTime(formatMetadata, streamMetadata map[string]string) (time.Time, bool) {
+	for _, metadata := range []map[string]string{formatMetadata, streamMetadata} {
+		if creationTime, err := time.Parse(time.RFC3339Nano, metadata["tag:creation_time"]); err == nil {
+			return creationTime, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Helper function used to probe the container format metadata of the video file specified by the path using ffprobe. An empty
+// metadata is returned if the probe failed, because the format metadata is optional.
+func probeVideoFormat(path string) map[string]string {
+	output, err := exec.Command("ffprobe", "-show_format", "-print_format", "compact", "-loglevel", "quiet", path).Output()
+	if err != nil {
+		return map[string]string{}
+	}
+
+	return parseFfprobeCompactSection(output, "format")
+}
+
+// Helper function used to parse the key-value pairs of the first section with the given name from the ffprobe compact output.
+func parseFfprobeCompactSection(output []byte, section string) map[string]string {
+	metadata := make(map[string]string)
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		fields := strings.Split(strings.TrimSpace(string(line)), "|")
+		if fields[0] != section {
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			if key, value, ok := strings.Cut(field, "="); ok {
+				if _, exists := metadata[key]; !exists {
+					metadata[key] = value
+				}
+			}
+		}
+
+		break
+	}
+
+	return metadata
+}
@@ -0,0 +1,43 @@
+package source
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldParseVideoTimingMetadata(t *testing.T) {
+	metadata := map[string]string{
+		"start_time":        "0.040000",
+		"tag:creation_time": "2024-06-01T21:30:05.000000Z",
+	}
+
+	assert.Equal(t, 0.04, parseVideoStartTime(metadata))
+
+	creationTime, ok := parseVideoCreationTime(map[string]string{}, metadata)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 1, 21, 30, 5, 0, time.UTC), creationTime)
+
+	assert.Equal(t, 0.0, parseVideoStartTime(map[string]string{"start_time": "N/A"}))
+
+	_, ok = parseVideoCreationTime(map[string]string{}, map[string]string{})
+	assert.False(t, ok)
+}
+
+func TestShouldPreferContainerCreationTime(t *testing.T) {
+	output := []byte("format|filename=sample.mp4|nb_streams=1|start_time=0.000000|tag:major_brand=isom|tag:creation_time=2024-06-01T21:30:05.000000Z\n")
+
+	formatMetadata := parseFfprobeCompactSection(output, "format")
+	assert.Equal(t, "sample.mp4", formatMetadata["filename"])
+
+	streamMetadata := map[string]string{"tag:creation_time": "2024-06-01T21:31:00.000000Z"}
+
+	creationTime, ok := parseVideoCreationTime(formatMetadata, streamMetadata)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 1, 21, 30, 5, 0, time.UTC), creationTime)
+
+	creationTime, ok = parseVideoCreationTime(parseFfprobeCompactSection([]byte("format|filename=sample.mp4\n"), "format"), streamMetadata)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 1, 21, 31, 0, 0, time.UTC), creationTime)
+}
@@ -0,0 +1,351 @@
+package tuning
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/detector"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/evaluation"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+)
+
+const (
+	FrameObjective string = "frame"
+	EventObjective string = "event"
+
+	GridSearchStrategy              string = "grid"
+	CoordinateDescentSearchStrategy string = "coordinate-descent"
+)
+
+// Structure representing the candidate values of the tuned detector options. The thresholds are stored by the metrics names.
+type SearchSpace struct {
+	Thresholds            map[string][]float64
+	MovingMeanResolutions []int32
+}
+
+// Structure representing a single evaluated combination of the detector options.
+type Candidate struct {
+	Options    detector.DetectorOptions
+	Evaluation *evaluation.Evaluation
+	Score      float64
+}
+
+// Tuner instance that is able to search for the detector options that give the best detection accuracy on the analyzed frames.
+type Tuner struct {
+	frames     *frame.FramesCollection
+	labels     []evaluation.FrameRange
+	options    detector.DetectorOptions
+	objective  string
+	statistics map[int32]frame.FramesStatistics
+	candidates map[string]*Candidate
+}
+
+// Create a new tuner instance for the analyzed frames and the labelled frames ranges. The not tuned detector options are taken
+// from the provided options, which must be valid, and the candidates are scored by the F1 score of the frame or the event objective.
+func CreateTuner(frames *frame.FramesCollection, labels []evaluation.FrameRange, options detector.DetectorOptions, objective string) (*Tuner, error) {
+	if frames == nil {
+		return nil, errors.New("tuning: invalid nil reference frames collection provided")
+	}
+
+	if objective != FrameObjective && objective != EventObjective {
+		return nil, fmt.Errorf("tuning: invalid objective %q specified", objective)
+	}
+
+	options.AutoThresholds = false
+	if valid, msg := options.AreValid(); !valid {
+		return nil, fmt.Errorf("tuning: invalid base detector options: %s", msg)
+	}
+
+	return &Tuner{
+		frames:     frames,
+		labels:     labels,
+		options:    options,
+		objective:  objective,
+		statistics: make(map[int32]frame.FramesStatistics),
+		candidates: make(map[string]*Candidate),
+	}, nil
+}
+
+// Search the space using the selected strategy and return all evaluated candidates ranked by the score. The combinations of the
+// options which are not valid are skipped and an error is returned if none of the evaluated combinations is valid.
+func (tuner *Tuner) Search(space SearchSpace, strategy string) ([]*Candidate, error) {
+	if err := validateSearchSpace(space); err != nil {
+		return nil, err
+	}
+
+	switch strategy {
+	case GridSearchStrategy:
+		tuner.gridSearch(space)
+	case CoordinateDescentSearchStrategy:
+		tuner.coordinateDescentSearch(space)
+	default:
+		return nil, fmt.Errorf("tuning: invalid search strategy %q specified", strategy)
+	}
+
+	candidates := tuner.getRankedCandidates()
+	if len(candidates) == 0 {
+		return nil, errors.New("tuning: none of the evaluated options combinations is valid")
+	}
+
+	return candidates, nil
+}
+
+// Helper function used to evaluate all combinations of the search space values.
+func (tuner *Tuner) gridSearch(space SearchSpace) {
+	names := frame.GetMetricsNames()
+	positions := make([]int, len(names)+1)
+	sizes := getSearchSpaceSizes(space)
+
+	for {
+		tuner.evaluate(space, positions)
+
+		axis := 0
+		for axis < len(positions) {
+			positions[axis] += 1
+			if positions[axis] < sizes[axis] {
+				break
+			}
+
+			positions[axis] = 0
+			axis += 1
+		}
+
+		if axis == len(positions) {
+			return
+		}
+	}
+}
+
+// Helper function used to optimize one option at a time while keeping the other options fixed, starting from the lowest values.
+// The search is finished when a full pass over all options does not improve the best score.
+func (tuner *Tuner) coordinateDescentSearch(space SearchSpace) {
+	positions := make([]int, len(frame.GetMetricsNames())+1)
+	sizes := getSearchSpaceSizes(space)
+	best := tuner.evaluate(space, positions)
+
+	for improved := true; improved; {
+		improved = false
+
+		for axis := range positions {
+			bestPosition := positions[axis]
+			for position := 0; position < sizes[axis]; position += 1 {
+				positions[axis] = position
+				if candidate := tuner.evaluate(space, positions); candidate != nil && (best == nil || candidate.Score > best.Score) {
+					best, bestPosition, improved = candidate, position, true
+				}
+			}
+
+			positions[axis] = bestPosition
+		}
+	}
+}
+
+// Helper function used to evaluate the options specified by the positions of the values in the search space. The last position
+// is representing the moving mean resolution. The candidates are memoized, so each combination is evaluated only once. Nil is
+// returned if the options are not valid.
+func (tuner *Tuner) evaluate(space SearchSpace, positions []int) *Candidate {
+	names := frame.GetMetricsNames()
+	options := tuner.options
+
+	key := strings.Builder{}
+	for axis, name := range names {
+		options.SetMetricThreshold(name, space.Thresholds[name][positions[axis]])
+		options.SetMetricThresholdExplicit(name)
+		key.WriteString(strconv.Itoa(positions[axis]) + ";")
+	}
+
+	options.MovingMeanResolution = space.MovingMeanResolutions[positions[len(names)]]
+	key.WriteString(strconv.Itoa(positions[len(names)]))
+
+	if candidate, ok := tuner.candidates[key.String()]; ok {
+		return candidate
+	}
+
+	if valid, _ := options.AreValid(); !valid {
+		tuner.candidates[key.String()] = nil
+		return nil
+	}
+
+	statistics, ok := tuner.statistics[options.MovingMeanResolution]
+	if !ok {
+		statistics = tuner.frames.CalculateStatistics(int(options.MovingMeanResolution))
+		tuner.statistics[options.MovingMeanResolution] = statistics
+	}
+
+	frames := tuner.frames.GetAll()
+	detections, events := detector.DetectFrames(frames, statistics, options)
+
+	eventsRanges := make([]evaluation.FrameRange, 0, len(events))
+	for _, event := range events {
+		eventsRanges = append(eventsRanges, evaluation.FrameRange{First: event.FirstFrame, Last: event.LastFrame})
+	}
+
+	analyzed := make([]int, 0, len(frames))
+	for _, f := range frames {
+		analyzed = append(analyzed, f.OrdinalNumber)
+	}
+
+	result := evaluation.EvaluateAnalyzedFrames(tuner.labels, detections, eventsRanges, analyzed)
+
+	candidate := &Candidate{
+		Options:    options,
+		Evaluation: result,
+		Score:      result.FrameScores.F1,
+	}
+
+	if tuner.objective == EventObjective {
+		candidate.Score = result.EventScores.F1
+	}
+
+	tuner.candidates[key.String()] = candidate
+	return candidate
+}
+
+// Helper function used to sort the evaluated candidates by the descending score. Ties are resolved by the other objective F1
+// score and then by the lower thresholds sum, so the more sensitive options are preferred.
+func (tuner *Tuner) getRankedCandidates() []*Candidate {
+	candidates := make([]*Candidate, 0, len(tuner.candidates))
+	for _, candidate := range tuner.candidates {
+		if candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	secondaryScore := func(candidate *Candidate) float64 {
+		if tuner.objective == EventObjective {
+			return candidate.Evaluation.FrameScores.F1
+		}
+
+		return candidate.Evaluation.EventScores.F1
+	}
+
+	thresholdsSum := func(candidate *Candidate) float64 {
+		sum := 0.0
+		for _, name := range frame.GetMetricsNames() {
+			sum += candidate.Options.GetMetricThreshold(name)
+		}
+
+		return sum
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+
+		if a, b := secondaryScore(candidates[i]), secondaryScore(candidates[j]); a != b {
+			return a > b
+		}
+
+		if a, b := thresholdsSum(candidates[i]), thresholdsSum(candidates[j]); a != b {
+			return a < b
+		}
+
+		return candidates[i].Options.MovingMeanResolution < candidates[j].Options.MovingMeanResolution
+	})
+
+	return candidates
+}
+
+// Return the number of the evaluated options combinations which were skipped because the options were not valid.
+func (tuner *Tuner) GetInvalidCombinationsCount() int {
+	count := 0
+	for _, candidate := range tuner.candidates {
+		if candidate == nil {
+			count += 1
+		}
+	}
+
+	return count
+}
+
+// Return the number of all options combinations of the search space, which are all evaluated by the grid search strategy.
+func GetSearchSpaceCombinationsCount(space SearchSpace) int {
+	count := 1
+	for _, size := range getSearchSpaceSizes(space) {
+		count *= size
+	}
+
+	return count
+}
+
+func getSearchSpaceSizes(space SearchSpace) []int {
+	sizes := make([]int, 0)
+	for _, name := range frame.GetMetricsNames() {
+		sizes = append(sizes, len(space.Thresholds[name]))
+	}
+
+	return append(sizes, len(space.MovingMeanResolutions))
+}
+
+func validateSearchSpace(space SearchSpace) error {
+	for _, name := range frame.GetMetricsNames() {
+		values, ok := space.Thresholds[name]
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("tuning: the search space does not contain the %s threshold values", name)
+		}
+
+		for _, value := range values {
+			if value < 0.0 || value > 1.0 {
+				return fmt.Errorf("tuning: the %s threshold values must be between zero and one", name)
+			}
+		}
+	}
+
+	if len(space.MovingMeanResolutions) == 0 {
+		return errors.New("tuning: the search space does not contain the moving mean resolution values")
+	}
+
+	for _, value := range space.MovingMeanResolutions {
+		if value < 1 {
+			return errors.New("tuning: the moving mean resolution values must be positive")
+		}
+	}
+
+	return nil
+}
+
+// Write the CSV format ranking of the candidates to the provided writer which can be a file reference.
+func ExportCandidatesCsvReport(file io.Writer, candidates []*Candidate) error {
+	csvWriter := csv.NewWriter(file)
+	names := frame.GetMetricsNames()
+
+	header := []string{"Rank"}
+	for _, name := range names {
+		header = append(header, name+" threshold")
+	}
+
+	header = append(header, "Moving mean resolution", "Score", "Frame precision", "Frame recall", "Frame F1", "Event precision", "Event recall", "Event F1")
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("tuning: failed to write the header to the tuning report file: %w", err)
+	}
+
+	for index, candidate := range candidates {
+		row := []string{strconv.Itoa(index + 1)}
+		for _, name := range names {
+			row = append(row, strconv.FormatFloat(candidate.Options.GetMetricThreshold(name), 'f', -1, 64))
+		}
+
+		row = append(row,
+			strconv.Itoa(int(candidate.Options.MovingMeanResolution)),
+			strconv.FormatFloat(candidate.Score, 'f', -1, 64),
+			strconv.FormatFloat(candidate.Evaluation.FrameScores.Precision, 'f', -1, 64),
+			strconv.FormatFloat(candidate.Evaluation.FrameScores.Recall, 'f', -1, 64),
+			strconv.FormatFloat(candidate.Evaluation.FrameScores.F1, 'f', -1, 64),
+			strconv.FormatFloat(candidate.Evaluation.EventScores.Precision, 'f', -1, 64),
+			strconv.FormatFloat(candidate.Evaluation.EventScores.Recall, 'f', -1, 64),
+			strconv.FormatFloat(candidate.Evaluation.EventScores.F1, 'f', -1, 64))
+
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("tuning: failed to write the candidate to the tuning report file: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	return nil
+}
@@ -0,0 +1,142 @@
+package tuning
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Krzysztofz01/video-lightning-detector/internal/detector"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/evaluation"
+	"github.com/Krzysztofz01/video-lightning-detector/internal/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTunerShouldFindOptionsMatchingTheLabels(t *testing.T) {
+	for _, strategy := range []string{GridSearchStrategy, CoordinateDescentSearchStrategy} {
+		tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{{First: 20, Last: 20}, {First: 60, Last: 60}}, detector.GetDefaultDetectorOptions(), FrameObjective)
+		assert.Nil(t, err)
+
+		candidates, err := tuner.Search(mockSearchSpace(), strategy)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, candidates)
+
+		best := candidates[0]
+		assert.Equal(t, 1.0, best.Score)
+		assert.Equal(t, 1.0, best.Evaluation.EventScores.F1)
+
+		for index := 1; index < len(candidates); index += 1 {
+			assert.GreaterOrEqual(t, candidates[index-1].Score, candidates[index].Score)
+		}
+	}
+}
+
+func TestTunerShouldEvaluateAllGridSearchCombinations(t *testing.T) {
+	tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{}, detector.GetDefaultDetectorOptions(), EventObjective)
+	assert.Nil(t, err)
+
+	candidates, err := tuner.Search(mockSearchSpace(), GridSearchStrategy)
+
+	assert.Nil(t, err)
+	assert.Len(t, candidates, 3*2*2*2)
+}
+
+func TestTunerShouldNotSearchInvalidSpaceOrStrategy(t *testing.T) {
+	tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{}, detector.GetDefaultDetectorOptions(), FrameObjective)
+	assert.Nil(t, err)
+
+	_, err = tuner.Search(mockSearchSpace(), "random")
+	assert.NotNil(t, err)
+
+	space := mockSearchSpace()
+	space.MovingMeanResolutions = []int32{}
+	_, err = tuner.Search(space, GridSearchStrategy)
+	assert.NotNil(t, err)
+
+	space = mockSearchSpace()
+	space.Thresholds[frame.BrightnessMetricName] = []float64{1.5}
+	_, err = tuner.Search(space, GridSearchStrategy)
+	assert.NotNil(t, err)
+}
+
+func TestTunerShouldNotCreateForInvalidObjective(t *testing.T) {
+	tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{}, detector.GetDefaultDetectorOptions(), "recall")
+
+	assert.Nil(t, tuner)
+	assert.NotNil(t, err)
+}
+
+func TestShouldExportCandidatesCsvReport(t *testing.T) {
+	tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{{First: 20, Last: 20}}, detector.GetDefaultDetectorOptions(), FrameObjective)
+	assert.Nil(t, err)
+
+	candidates, err := tuner.Search(mockSearchSpace(), GridSearchStrategy)
+	assert.Nil(t, err)
+
+	buffer := &bytes.Buffer{}
+	err = ExportCandidatesCsvReport(buffer, candidates)
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(candidates)+1, strings.Count(buffer.String(), "\n"))
+}
+
+func mockFramesCollection(t *testing.T) *frame.FramesCollection {
+	collection := frame.CreateNewFramesCollection(80)
+	for index := 0; index < 80; index += 1 {
+		f := &frame.Frame{OrdinalNumber: index + 1, Metrics: make(map[string]float64)}
+		for _, name := range frame.GetMetricsNames() {
+			f.Metrics[name] = 0.1 + 0.01*float64(index%3)
+			if f.OrdinalNumber == 20 || f.OrdinalNumber == 60 {
+				f.Metrics[name] = 0.6
+			}
+		}
+
+		assert.Nil(t, collection.Append(f))
+	}
+
+	return collection
+}
+
+func mockSearchSpace() SearchSpace {
+	return SearchSpace{
+		Thresholds: map[string][]float64{
+			frame.BrightnessMetricName:                {0.0, 0.1, 0.2},
+			frame.ColorDifferenceMetricName:           {0.0, 0.2},
+			frame.BinaryThresholdDifferenceMetricName: {0.0, 0.2},
+		},
+		MovingMeanResolutions: []int32{10, 30},
+	}
+}
+
+func TestTunerShouldNotCreateForInvalidBaseOptions(t *testing.T) {
+	options := detector.GetDefaultDetectorOptions()
+	options.ZScoreSigma = -1.0
+
+	tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{}, options, FrameObjective)
+	assert.NotNil(t, err)
+	assert.Nil(t, tuner)
+}
+
+func TestTunerShouldSkipInvalidOptionsCombinations(t *testing.T) {
+	for _, strategy := range []string{GridSearchStrategy, CoordinateDescentSearchStrategy} {
+		tuner, err := CreateTuner(mockFramesCollection(t), []evaluation.FrameRange{}, detector.GetDefaultDetectorOptions(), FrameObjective)
+		assert.Nil(t, err)
+
+		tuner.options.CombinationRule = "none"
+
+		candidates, err := tuner.Search(mockSearchSpace(), strategy)
+		assert.NotNil(t, err)
+		assert.Nil(t, candidates)
+		assert.NotZero(t, tuner.GetInvalidCombinationsCount())
+	}
+}
+
+func TestShouldCountSearchSpaceCombinations(t *testing.T) {
+	space := mockSearchSpace()
+
+	expected := len(space.MovingMeanResolutions)
+	for _, values := range space.Thresholds {
+		expected *= len(values)
+	}
+
+	assert.Equal(t, expected, GetSearchSpaceCombinationsCount(space))
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	_ "image/jpeg"
 	"image/png"
+	"io"
 	"os"
 	"path/filepath"
 )
@@ -49,3 +51,51 @@ func ExportImageAsPng(path string, img image.Image) error {
 
 	return nil
 }
+
+// Open the image file at the given path and decode it. The PNG and JPEG formats are supported.
+func ImportImage(path string) (image.Image, error) {
+	if len(path) == 0 {
+		return nil, errors.New("utils: invalid image path specified")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("utils: failed to open the image file: %w", err)
+	}
+
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("utils: failed to decode the image: %w", err)
+	}
+
+	return img, nil
+}
+
+// Copy the file at the source path to the destination path. The destination directory tree is created if not present.
+func CopyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("utils: failed to open the source file: %w", err)
+	}
+
+	defer src.Close()
+
+	dst, err := CreateFileWithTree(dstPath)
+	if err != nil {
+		return fmt.Errorf("utils: failed to create the destination file: %w", err)
+	}
+
+	defer func() {
+		if err := dst.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("utils: failed to copy the file content: %w", err)
+	}
+
+	return nil
+}
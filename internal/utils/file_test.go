@@ -56,3 +56,53 @@ func TestShouldExportImageAsPngForValidPathAndImage(t *testing.T) {
 	// NOTE: Cleanup
 	os.RemoveAll(path)
 }
+
+func TestShouldNotImportImageForEmptyPath(t *testing.T) {
+	img, err := ImportImage("")
+
+	assert.NotNil(t, err)
+	assert.Nil(t, img)
+}
+
+func TestShouldImportExportedPngImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.White)
+
+	path := "test/test_import_image.png"
+
+	err := ExportImageAsPng(path, img)
+	assert.Nil(t, err)
+
+	actual, err := ImportImage(path)
+	assert.Nil(t, err)
+	assert.NotNil(t, actual)
+	assert.Equal(t, img.Bounds(), actual.Bounds())
+	assert.Equal(t, ColorToRgba(img.At(0, 0)), ColorToRgba(actual.At(0, 0)))
+
+	// NOTE: Cleanup
+	os.RemoveAll("test")
+}
+
+func TestShouldCopyFile(t *testing.T) {
+	srcPath := "test_copy_source.txt"
+	dstPath := "test/test_copy_destination.txt"
+
+	assert.Nil(t, os.WriteFile(srcPath, []byte("lightning"), 0660))
+
+	err := CopyFile(srcPath, dstPath)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(dstPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "lightning", string(content))
+
+	// NOTE: Cleanup
+	os.Remove(srcPath)
+	os.RemoveAll("test")
+}
+
+func TestShouldNotCopyMissingFile(t *testing.T) {
+	err := CopyFile("test_missing_file.txt", "test/test_missing_file.txt")
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"errors"
+	"image"
+	"math"
+)
+
+const (
+	colorHistogramChannelBins  int = 8
+	colorHistogramChannelShift int = 5
+)
+
+// Create the normalized joint RGB color histogram of the image. Each color channel is quantized into eight bins, so the
+// histogram contains 512 bins which values sum up to one.
+func CreateColorHistogram(img *image.RGBA) ([]float64, error) {
+	if img == nil {
+		return nil, errors.New("utils: the image reference is nil")
+	}
+
+	histogram := make([]float64, colorHistogramChannelBins*colorHistogramChannelBins*colorHistogramChannelBins)
+
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width == 0 || height == 0 {
+		return histogram, nil
+	}
+
+	for y := 0; y < height; y += 1 {
+		row := img.Pix[y*img.Stride : y*img.Stride+width*4]
+		for x := 0; x < width*4; x += 4 {
+			r, g, b := int(row[x])>>colorHistogramChannelShift, int(row[x+1])>>colorHistogramChannelShift, int(row[x+2])>>colorHistogramChannelShift
+			histogram[(r*colorHistogramChannelBins+g)*colorHistogramChannelBins+b] += 1
+		}
+	}
+
+	for index := range histogram {
+		histogram[index] /= float64(width * height)
+	}
+
+	return histogram, nil
+}
+
+// Calculate the distance between two normalized histograms represented as a value from zero to one, which is the half of the
+// sum of the absolute differences of the bins values. Zero means identical histograms and one means disjoint histograms.
+func HistogramDistance(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, errors.New("utils: histograms bins count missmatch")
+	}
+
+	distance := 0.0
+	for index := range a {
+		distance += math.Abs(a[index] - b[index])
+	}
+
+	return distance / 2.0, nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateColorHistogramShouldReturnErrorForNilImage(t *testing.T) {
+	_, err := CreateColorHistogram(nil)
+	assert.NotNil(t, err)
+}
+
+func TestCreateColorHistogramShouldCreateNormalizedHistogram(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y += 1 {
+		for x := 0; x < 4; x += 1 {
+			if x == 0 {
+				img.SetRGBA(x, y, color.RGBA{255, 255, 255, 255})
+			} else {
+				img.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
+			}
+		}
+	}
+
+	histogram, err := CreateColorHistogram(img)
+	assert.Nil(t, err)
+	assert.Len(t, histogram, 512)
+	assert.InDelta(t, 0.75, histogram[0], 1e-9)
+	assert.InDelta(t, 0.25, histogram[511], 1e-9)
+	assert.InDelta(t, 1.0/512.0, Mean(histogram), 1e-9)
+}
+
+func TestHistogramDistanceShouldCalculateDistance(t *testing.T) {
+	cases := []struct {
+		a        []float64
+		b        []float64
+		expected float64
+	}{
+		{[]float64{0.5, 0.5}, []float64{0.5, 0.5}, 0.0},
+		{[]float64{1.0, 0.0}, []float64{0.0, 1.0}, 1.0},
+		{[]float64{0.75, 0.25}, []float64{0.25, 0.75}, 0.5},
+	}
+
+	for _, c := range cases {
+		distance, err := HistogramDistance(c.a, c.b)
+		assert.Nil(t, err)
+		assert.InDelta(t, c.expected, distance, 1e-9)
+	}
+
+	_, err := HistogramDistance([]float64{1.0}, []float64{0.5, 0.5})
+	assert.NotNil(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"image/color"
 
 	"github.com/esimov/stackblur-go"
 	"golang.org/x/image/draw"
@@ -61,3 +62,57 @@ func ScaleImage(src, dst *image.RGBA, factor float64) error {
 
 	return nil
 }
+
+// Perform a lighten blending of the source image onto the destination image by keeping the maximum value of each pixel component.
+func LightenBlendImage(src, dst *image.RGBA) error {
+	if src == nil {
+		return errors.New("utils: the source image reference is nil")
+	}
+
+	if dst == nil {
+		return errors.New("utils: the destination image pointer is nil")
+	}
+
+	if src.Bounds().Dx() != dst.Bounds().Dx() || src.Bounds().Dy() != dst.Bounds().Dy() {
+		return errors.New("utils: source and destination images bounds missmatch")
+	}
+
+	for index := 0; index < len(dst.Pix); index += 1 {
+		if src.Pix[index] > dst.Pix[index] {
+			dst.Pix[index] = src.Pix[index]
+		}
+	}
+
+	return nil
+}
+
+// Draw the outline of the rectangle with the given color and thickness in pixels onto the destination image. The outline is drawn
+// inside the rectangle and the parts of the rectangle outside the image bounds are clipped.
+func DrawRectangle(dst *image.RGBA, rect image.Rectangle, c color.RGBA, thickness int) error {
+	if dst == nil {
+		return errors.New("utils: the destination image pointer is nil")
+	}
+
+	if thickness <= 0 {
+		return errors.New("utils: the rectangle thickness must be greater than zero")
+	}
+
+	rect = rect.Canon()
+	inner := rect.Inset(thickness)
+	if inner.Dx() <= 0 || inner.Dy() <= 0 {
+		inner = image.Rectangle{}
+	}
+
+	clipped := rect.Intersect(dst.Bounds())
+	for y := clipped.Min.Y; y < clipped.Max.Y; y += 1 {
+		for x := clipped.Min.X; x < clipped.Max.X; x += 1 {
+			if image.Pt(x, y).In(inner) {
+				continue
+			}
+
+			dst.SetRGBA(x, y, c)
+		}
+	}
+
+	return nil
+}
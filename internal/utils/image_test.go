@@ -145,3 +145,63 @@ func TestScaleShouldScaleGivenImageWithSameSize(t *testing.T) {
 	err := ScaleImage(sourceImage, destinationImage, 1.0)
 	assert.Nil(t, err)
 }
+
+func TestLightenBlendImageShouldReturnErrorForNilSource(t *testing.T) {
+	destinationImage := image.NewRGBA(image.Rect(0, 0, 1, 1))
+
+	err := LightenBlendImage(nil, destinationImage)
+	assert.NotNil(t, err)
+}
+
+func TestLightenBlendImageShouldReturnErrorForNilDestination(t *testing.T) {
+	sourceImage := image.NewRGBA(image.Rect(0, 0, 1, 1))
+
+	err := LightenBlendImage(sourceImage, nil)
+	assert.NotNil(t, err)
+}
+
+func TestLightenBlendImageShouldReturnErrorOnSourceDestinationImageSizeMissmatch(t *testing.T) {
+	sourceImage := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	destinationImage := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	err := LightenBlendImage(sourceImage, destinationImage)
+	assert.NotNil(t, err)
+}
+
+func TestLightenBlendImageShouldKeepMaximumComponents(t *testing.T) {
+	sourceImage := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	sourceImage.Set(0, 0, color.RGBA{200, 10, 50, 255})
+	sourceImage.Set(1, 0, color.RGBA{0, 0, 0, 255})
+
+	destinationImage := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	destinationImage.Set(0, 0, color.RGBA{100, 100, 100, 255})
+	destinationImage.Set(1, 0, color.RGBA{20, 30, 40, 255})
+
+	err := LightenBlendImage(sourceImage, destinationImage)
+	assert.Nil(t, err)
+
+	assert.Equal(t, color.RGBA{200, 100, 100, 255}, destinationImage.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{20, 30, 40, 255}, destinationImage.RGBAAt(1, 0))
+}
+
+func TestDrawRectangleShouldReturnErrorForNilDestination(t *testing.T) {
+	err := DrawRectangle(nil, image.Rect(0, 0, 2, 2), color.RGBA{255, 0, 0, 255}, 1)
+	assert.NotNil(t, err)
+}
+
+func TestDrawRectangleShouldDrawClippedOutline(t *testing.T) {
+	destinationImage := image.NewRGBA(image.Rect(0, 0, 6, 6))
+	red := color.RGBA{255, 0, 0, 255}
+
+	err := DrawRectangle(destinationImage, image.Rect(1, 1, 8, 5), red, 1)
+	assert.Nil(t, err)
+
+	assert.Equal(t, red, destinationImage.RGBAAt(1, 1))
+	assert.Equal(t, red, destinationImage.RGBAAt(5, 1))
+	assert.Equal(t, red, destinationImage.RGBAAt(1, 4))
+	assert.Equal(t, red, destinationImage.RGBAAt(3, 4))
+	assert.Equal(t, color.RGBA{}, destinationImage.RGBAAt(2, 2))
+	assert.Equal(t, color.RGBA{}, destinationImage.RGBAAt(5, 3))
+	assert.Equal(t, color.RGBA{}, destinationImage.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{}, destinationImage.RGBAAt(1, 5))
+}
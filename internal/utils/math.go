@@ -1,6 +1,9 @@
 package utils
 
-import "math"
+import (
+	"math"
+	"sort"
+)
 
 // Calculate the mean value of the provided set. Panic if the value set is empty.
 func Mean(x []float64) float64 {
@@ -56,6 +59,31 @@ func StandardDeviation(x []float64) float64 {
 
 }
 
+// Calculate the moving population standard deviation value of the provided set. The position paramter is the index of the
+// central subset element and the bias is the amount of "left" and "right" neighbours. Elements out of index are not taken
+// under account.
+func MovingStandardDeviation(x []float64, position, bias int) float64 {
+	if len(x) == 0 {
+		panic("utils: can not calculate the standard deviation of an empty set")
+	}
+
+	if position >= len(x) {
+		panic("utils: the position is out of bounds of the value set")
+	}
+
+	first := position - bias
+	if first < 0 {
+		first = 0
+	}
+
+	last := position + bias + 1
+	if last > len(x) {
+		last = len(x)
+	}
+
+	return StandardDeviation(x[first:last])
+}
+
 // Calcualte the max value of the provided set. Panic if the value set is empty.
 func Max(x []float64) float64 {
 	if len(x) == 0 {
@@ -72,6 +100,77 @@ func Max(x []float64) float64 {
 	return max
 }
 
+// Calculate the median value of the provided set. Panic if the value set is empty.
+func Median(x []float64) float64 {
+	if len(x) == 0 {
+		panic("utils: can not calculate the median of an empty set")
+	}
+
+	return Percentile(x, 50)
+}
+
+// Calculate the moving median value of the provided set. The position paramter is the index of the central subset element
+// and the bias is the amount of "left" and "right" neighbours. Elements out of index are not taken under account.
+func MovingMedian(x []float64, position, bias int) float64 {
+	if len(x) == 0 {
+		panic("utils: can not calculate the median of an empty set")
+	}
+
+	if position >= len(x) {
+		panic("utils: the position is out of bounds of the value set")
+	}
+
+	first := position - bias
+	if first < 0 {
+		first = 0
+	}
+
+	last := position + bias + 1
+	if last > len(x) {
+		last = len(x)
+	}
+
+	return Median(x[first:last])
+}
+
+// Calculate the median absolute deviation value of the provided set, which is the median of the absolute differences between
+// the values and the set median. Panic if the value set is empty.
+func MedianAbsoluteDeviation(x []float64) float64 {
+	if len(x) == 0 {
+		panic("utils: can not calculate the median absolute deviation of an empty set")
+	}
+
+	median := Median(x)
+	deviations := make([]float64, 0, len(x))
+	for _, value := range x {
+		deviations = append(deviations, math.Abs(value-median))
+	}
+
+	return Median(deviations)
+}
+
+// Calculate the percentile value of the provided set using the linear interpolation between the closest ranks. The percentile
+// must be between zero and one hundred. Panic if the value set is empty or the percentile is out of range.
+func Percentile(x []float64, percentile float64) float64 {
+	if len(x) == 0 {
+		panic("utils: can not calculate the percentile of an empty set")
+	}
+
+	if percentile < 0 || percentile > 100 {
+		panic("utils: the percentile must be between zero and one hundred")
+	}
+
+	sorted := make([]float64, len(x))
+	copy(sorted, x)
+	sort.Float64s(sorted)
+
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
 // Return the smaller value of x or y. This functions does not support the edge cases like math.Min
 func MinInt(x, y int) int {
 	if x < y {
@@ -80,3 +179,12 @@ func MinInt(x, y int) int {
 		return y
 	}
 }
+
+// Return the larger value of x or y. This functions does not support the edge cases like math.Max
+func MaxInt(x, y int) int {
+	if x > y {
+		return x
+	} else {
+		return y
+	}
+}
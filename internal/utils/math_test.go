@@ -110,3 +110,142 @@ func TestMinIntShoudlReturnTheSmallerValues(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	}
 }
+
+func TestMaxIntShouldReturnTheLargerValues(t *testing.T) {
+	cases := map[struct {
+		x int
+		y int
+	}]int{
+		{0, 1}:  1,
+		{0, -1}: 0,
+		{1, 1}:  1,
+		{2, 1}:  2,
+	}
+
+	for c, expected := range cases {
+		actual := MaxInt(c.x, c.y)
+
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestMedianShouldPanicForEmptyValueSet(t *testing.T) {
+	assert.Panics(t, func() {
+		Median([]float64{})
+	})
+}
+
+func TestMedianShouldCalculateMedianForValueSet(t *testing.T) {
+	cases := []struct {
+		set      []float64
+		expected float64
+	}{
+		{[]float64{5}, 5},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+		{[]float64{1, 1, 1, 100}, 1},
+	}
+
+	const delta float64 = 1e-7
+	for _, c := range cases {
+		actual := Median(c.set)
+
+		assert.InDelta(t, c.expected, actual, delta)
+	}
+}
+
+func TestMovingMedianShouldPanicForPositionOutOfBounds(t *testing.T) {
+	assert.Panics(t, func() {
+		MovingMedian([]float64{1, 2}, 2, 2)
+	})
+}
+
+func TestMovingMedianShouldCalculateMedianForValueSet(t *testing.T) {
+	cases := []struct {
+		set      []float64
+		position int
+		bias     int
+		expected float64
+	}{
+		{[]float64{3, 2, 1, 2, 30, 4}, 0, 1, 2.5},
+		{[]float64{3, 2, 1, 2, 30, 4}, 1, 1, 2.0},
+		{[]float64{3, 2, 1, 2, 30, 4}, 3, 1, 2.0},
+		{[]float64{3, 2, 1, 2, 30, 4}, 4, 1, 4.0},
+		{[]float64{3, 2, 1, 2, 30, 4}, 5, 1, 17.0},
+	}
+
+	const delta float64 = 1e-7
+	for _, c := range cases {
+		actual := MovingMedian(c.set, c.position, c.bias)
+
+		assert.InDelta(t, c.expected, actual, delta)
+	}
+}
+
+func TestMedianAbsoluteDeviationShouldCalculateDeviationForValueSet(t *testing.T) {
+	values := []float64{1, 1, 2, 2, 4, 6, 9}
+	expected := 1.0
+
+	const delta float64 = 1e-7
+
+	actual := MedianAbsoluteDeviation(values)
+
+	assert.InDelta(t, expected, actual, delta)
+}
+
+func TestPercentileShouldPanicForInvalidPercentile(t *testing.T) {
+	assert.Panics(t, func() {
+		Percentile([]float64{1, 2}, -1)
+	})
+
+	assert.Panics(t, func() {
+		Percentile([]float64{1, 2}, 101)
+	})
+}
+
+func TestPercentileShouldCalculatePercentileForValueSet(t *testing.T) {
+	values := []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5}
+	cases := map[float64]float64{
+		0:   1,
+		50:  5.5,
+		90:  9.1,
+		100: 10,
+	}
+
+	const delta float64 = 1e-7
+	for percentile, expected := range cases {
+		actual := Percentile(values, percentile)
+
+		assert.InDelta(t, expected, actual, delta)
+	}
+
+	assert.Equal(t, []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5}, values)
+}
+
+func TestMovingStandardDeviationShouldPanicForPositionOutOfBounds(t *testing.T) {
+	assert.Panics(t, func() {
+		MovingStandardDeviation([]float64{1, 2}, 2, 2)
+	})
+}
+
+func TestMovingStandardDeviationShouldCalculateStandardDeviationForValueSet(t *testing.T) {
+	cases := []struct {
+		set      []float64
+		position int
+		bias     int
+		expected float64
+	}{
+		{[]float64{3, 2, 1, 2, 3, 4}, 0, 1, 0.5},
+		{[]float64{3, 2, 1, 2, 3, 4}, 1, 1, 0.816497},
+		{[]float64{3, 3, 3, 2, 3, 4}, 1, 1, 0.0},
+		{[]float64{3, 2, 1, 2, 3, 4}, 5, 1, 0.5},
+		{[]float64{3, 2, 1, 2, 3, 4}, 2, 5, 0.957427},
+	}
+
+	const delta float64 = 1e-5
+	for _, c := range cases {
+		actual := MovingStandardDeviation(c.set, c.position, c.bias)
+
+		assert.InDelta(t, c.expected, actual, delta)
+	}
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"errors"
+	"image"
+	"math"
+)
+
+const (
+	motionPyramidMinimumSize   int = 32
+	motionPyramidMinimumRadius int = 2
+)
+
+// Estimate the global translation between the previous and the current image using the block matching of the grayscale images
+// on a coarse-to-fine pyramid. The shift is the offset by which the content of the previous image moved in the current image and
+// is not greater than the maximal shift in both directions. The mean of each image is subtracted before the comparison, so the
+// global brightness change, such as the lightning flash, does not affect the estimation. Zero shift is preferred for the images
+// without any texture.
+func EstimateTranslation(current, previous *image.RGBA, maxShift int) (int, int, error) {
+	if current == nil || previous == nil {
+		return 0, 0, errors.New("utils: the current or the previous image reference is nil")
+	}
+
+	if current.Bounds().Dx() != previous.Bounds().Dx() || current.Bounds().Dy() != previous.Bounds().Dy() {
+		return 0, 0, errors.New("utils: current and previous images bounds missmatch")
+	}
+
+	if maxShift < 0 {
+		return 0, 0, errors.New("utils: the maximal shift must not be negative")
+	}
+
+	width, height := current.Bounds().Dx(), current.Bounds().Dy()
+	maxShift = MinInt(maxShift, MinInt(width, height)/2)
+
+	currentLevels := []grayImage{createGrayImage(current)}
+	previousLevels := []grayImage{createGrayImage(previous)}
+	for {
+		last := currentLevels[len(currentLevels)-1]
+		if MinInt(last.width, last.height)/2 < motionPyramidMinimumSize || maxShift>>len(currentLevels) < motionPyramidMinimumRadius {
+			break
+		}
+
+		currentLevels = append(currentLevels, last.downscale())
+		previousLevels = append(previousLevels, previousLevels[len(previousLevels)-1].downscale())
+	}
+
+	level := len(currentLevels) - 1
+	radius := int(math.Ceil(float64(maxShift) / float64(int(1)<<level)))
+	shiftX, shiftY := matchGrayImages(currentLevels[level], previousLevels[level], 0, 0, radius, radius)
+
+	for level -= 1; level >= 0; level -= 1 {
+		levelMaxShift := int(math.Ceil(float64(maxShift) / float64(int(1)<<level)))
+		shiftX, shiftY = matchGrayImages(currentLevels[level], previousLevels[level], shiftX*2, shiftY*2, 1, levelMaxShift)
+	}
+
+	return MaxInt(-maxShift, MinInt(maxShift, shiftX)), MaxInt(-maxShift, MinInt(maxShift, shiftY)), nil
+}
+
+// Translate the source image by the given shift and store the result to the destination image. The destination pixels which
+// are not covered by the translated source image are copied from the fill image, so they do not differ from the fill image.
+func TranslateImage(src, dst, fill *image.RGBA, shiftX, shiftY int) error {
+	if src == nil || fill == nil {
+		return errors.New("utils: the source or the fill image reference is nil")
+	}
+
+	if dst == nil {
+		return errors.New("utils: the destination image pointer is nil")
+	}
+
+	if src.Bounds().Dx() != dst.Bounds().Dx() || src.Bounds().Dy() != dst.Bounds().Dy() || fill.Bounds().Dx() != dst.Bounds().Dx() || fill.Bounds().Dy() != dst.Bounds().Dy() {
+		return errors.New("utils: source, destination and fill images bounds missmatch")
+	}
+
+	width, height := dst.Bounds().Dx(), dst.Bounds().Dy()
+	for y := 0; y < height; y += 1 {
+		dstRow := dst.Pix[y*dst.Stride : y*dst.Stride+width*4]
+		fillRow := fill.Pix[y*fill.Stride : y*fill.Stride+width*4]
+
+		srcY := y - shiftY
+		if srcY < 0 || srcY >= height {
+			copy(dstRow, fillRow)
+			continue
+		}
+
+		first, last := MaxInt(0, shiftX), MinInt(width, width+shiftX)
+		if first >= last {
+			copy(dstRow, fillRow)
+			continue
+		}
+
+		copy(dstRow[:first*4], fillRow[:first*4])
+		copy(dstRow[first*4:last*4], src.Pix[srcY*src.Stride+(first-shiftX)*4:srcY*src.Stride+(last-shiftX)*4])
+		copy(dstRow[last*4:], fillRow[last*4:])
+	}
+
+	return nil
+}
+
+// Structure representing the grayscale image with the mean value subtracted from the pixels values.
+type grayImage struct {
+	width  int
+	height int
+	values []float64
+}
+
+func createGrayImage(img *image.RGBA) grayImage {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	gray := grayImage{
+		width:  width,
+		height: height,
+		values: make([]float64, width*height),
+	}
+
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			offset := y*img.Stride + x*4
+			gray.values[y*width+x] = (float64(img.Pix[offset])*0.299 + float64(img.Pix[offset+1])*0.587 + float64(img.Pix[offset+2])*0.114) / 255.0
+		}
+	}
+
+	gray.subtractMean()
+	return gray
+}
+
+func (gray grayImage) downscale() grayImage {
+	scaled := grayImage{
+		width:  gray.width / 2,
+		height: gray.height / 2,
+		values: make([]float64, (gray.width/2)*(gray.height/2)),
+	}
+
+	for y := 0; y < scaled.height; y += 1 {
+		for x := 0; x < scaled.width; x += 1 {
+			index := 2*y*gray.width + 2*x
+			scaled.values[y*scaled.width+x] = (gray.values[index] + gray.values[index+1] + gray.values[index+gray.width] + gray.values[index+gray.width+1]) / 4.0
+		}
+	}
+
+	scaled.subtractMean()
+	return scaled
+}
+
+func (gray grayImage) subtractMean() {
+	mean := Mean(gray.values)
+	for index := range gray.values {
+		gray.values[index] -= mean
+	}
+}
+
+// Helper function used to find the shift with the lowest mean absolute difference of the overlapping pixels in the given radius
+// around the initial shift, limited by the maximal shift. The initial shift is preferred if the differences are equal.
+func matchGrayImages(current, previous grayImage, initialX, initialY, radius, maxShift int) (int, int) {
+	bestX, bestY := initialX, initialY
+	bestCost := calculateShiftCost(current, previous, initialX, initialY)
+
+	for shiftY := MaxInt(initialY-radius, -maxShift); shiftY <= MinInt(initialY+radius, maxShift); shiftY += 1 {
+		for shiftX := MaxInt(initialX-radius, -maxShift); shiftX <= MinInt(initialX+radius, maxShift); shiftX += 1 {
+			if cost := calculateShiftCost(current, previous, shiftX, shiftY); cost < bestCost {
+				bestX, bestY, bestCost = shiftX, shiftY, cost
+			}
+		}
+	}
+
+	return bestX, bestY
+}
+
+// Helper function used to calculate the mean absolute difference between the current image pixels and the previous image pixels
+// shifted by the given offset. The infinity is returned if the images do not overlap.
+func calculateShiftCost(current, previous grayImage, shiftX, shiftY int) float64 {
+	firstX, lastX := MaxInt(0, shiftX), MinInt(current.width, current.width+shiftX)
+	firstY, lastY := MaxInt(0, shiftY), MinInt(current.height, current.height+shiftY)
+	if firstX >= lastX || firstY >= lastY {
+		return math.Inf(1)
+	}
+
+	cost := 0.0
+	for y := firstY; y < lastY; y += 1 {
+		currentRow := current.values[y*current.width : (y+1)*current.width]
+		previousRow := previous.values[(y-shiftY)*previous.width : (y-shiftY+1)*previous.width]
+		for x := firstX; x < lastX; x += 1 {
+			cost += math.Abs(currentRow[x] - previousRow[x-shiftX])
+		}
+	}
+
+	return cost / float64((lastX-firstX)*(lastY-firstY))
+}
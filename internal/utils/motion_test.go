@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTranslationShouldReturnErrorForInvalidImages(t *testing.T) {
+	_, _, err := EstimateTranslation(nil, image.NewRGBA(image.Rect(0, 0, 2, 2)), 1)
+	assert.NotNil(t, err)
+
+	_, _, err = EstimateTranslation(image.NewRGBA(image.Rect(0, 0, 2, 2)), image.NewRGBA(image.Rect(0, 0, 3, 2)), 1)
+	assert.NotNil(t, err)
+}
+
+func TestEstimateTranslationShouldEstimateShift(t *testing.T) {
+	previous := mockTexturedImage(160, 120, 0)
+
+	cases := [][2]int{{0, 0}, {5, -3}, {-12, 7}, {1, 1}}
+	for _, c := range cases {
+		current := image.NewRGBA(previous.Rect)
+		assert.Nil(t, TranslateImage(previous, current, mockTexturedImage(160, 120, 1), c[0], c[1]))
+
+		shiftX, shiftY, err := EstimateTranslation(current, previous, 16)
+		assert.Nil(t, err)
+		assert.Equal(t, c[0], shiftX)
+		assert.Equal(t, c[1], shiftY)
+	}
+}
+
+func TestEstimateTranslationShouldIgnoreBrightnessChange(t *testing.T) {
+	previous := mockTexturedImage(160, 120, 0)
+	current := image.NewRGBA(previous.Rect)
+	assert.Nil(t, TranslateImage(previous, current, previous, 4, 2))
+
+	for index := range current.Pix {
+		if index%4 != 3 {
+			current.Pix[index] = uint8(MinInt(255, int(current.Pix[index])+60))
+		}
+	}
+
+	shiftX, shiftY, err := EstimateTranslation(current, previous, 16)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, shiftX)
+	assert.Equal(t, 2, shiftY)
+}
+
+func TestEstimateTranslationShouldPreferZeroShiftForUniformImages(t *testing.T) {
+	current := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	previous := image.NewRGBA(image.Rect(0, 0, 64, 64))
+
+	shiftX, shiftY, err := EstimateTranslation(current, previous, 8)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, shiftX)
+	assert.Equal(t, 0, shiftY)
+}
+
+func TestTranslateImageShouldFillUncoveredPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.SetRGBA(0, 0, color.RGBA{10, 10, 10, 255})
+	src.SetRGBA(1, 0, color.RGBA{20, 20, 20, 255})
+
+	fill := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y += 1 {
+		for x := 0; x < 3; x += 1 {
+			fill.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	assert.Nil(t, TranslateImage(src, dst, fill, 1, 1))
+
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, dst.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, dst.RGBAAt(0, 1))
+	assert.Equal(t, color.RGBA{10, 10, 10, 255}, dst.RGBAAt(1, 1))
+	assert.Equal(t, color.RGBA{20, 20, 20, 255}, dst.RGBAAt(2, 1))
+
+	assert.NotNil(t, TranslateImage(src, nil, fill, 0, 0))
+}
+
+func mockTexturedImage(width, height int, seed int64) *image.RGBA {
+	random := rand.New(rand.NewSource(seed))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// NOTE: The noise blocks are larger than a single pixel, so the texture is preserved on the coarse pyramid levels.
+	for y := 0; y < height; y += 4 {
+		for x := 0; x < width; x += 4 {
+			c := color.RGBA{uint8(random.Intn(200)), uint8(random.Intn(200)), uint8(random.Intn(200)), 255}
+			for by := y; by < MinInt(y+4, height); by += 1 {
+				for bx := x; bx < MinInt(x+4, width); bx += 1 {
+					img.SetRGBA(bx, by, c)
+				}
+			}
+		}
+	}
+
+	return img
+}